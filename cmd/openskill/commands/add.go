@@ -6,6 +6,7 @@ import (
 	"openskill/pkg/core"
 	"openskill/pkg/llm"
 	"openskill/pkg/skills"
+	"openskill/pkg/usage"
 
 	"github.com/spf13/cobra"
 )
@@ -38,7 +39,7 @@ var AddCmd = &cobra.Command{
 				return fmt.Errorf("API key not configured. Set it with:\n\n  openskill config set api-key\n\nOr use --manual flag to skip AI generation")
 			}
 			fmt.Printf("Generating skill with %s...\n", gen.ProviderName())
-			enhanced, err := gen.EnhanceSkill(name, addDesc)
+			enhanced, err := gen.EnhanceSkill(usage.WithSkill(cmd.Context(), name), name, addDesc)
 			if err != nil {
 				return fmt.Errorf("AI generation failed: %w", err)
 			}
@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"openskill/pkg/eval"
+	"openskill/pkg/llm"
+	"openskill/pkg/render"
+	"openskill/pkg/skills"
+	"openskill/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+var benchTargets []string
+var benchVars []string
+var benchExec bool
+var benchParallel int
+var benchNoJudge bool
+var benchMinPass float64
+var benchJSON string
+var benchMarkdown string
+
+var BenchCmd = &cobra.Command{
+	Use:   "bench <skill-name>",
+	Short: "Benchmark a skill's test suite across providers and models",
+	Long: `Run a skill's tests.yaml against several provider/model pairs and compare
+them side by side: pass rate, rule compliance judged by a model, median and
+p95 latency, token usage and cost.
+
+Targets are given as provider or provider:model with --target, repeated or
+comma-separated; without any, every configured provider is run with its
+configured model. Responses bypass the response cache so latencies are
+real. One judge scores every target, so scores are comparable: the default
+provider unless --judge-provider and --judge-model say otherwise.
+
+The cheapest target whose pass rate reaches --min-pass is recommended.
+--json and --markdown write the comparison to report files.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  openskill bench code-review --target groq:llama-3.1-8b-instant --target openai:gpt-4o-mini --target anthropic
+  openskill bench code-review --target ollama:llama3:8b,groq --min-pass 90 --markdown bench.md
+  openskill bench code-review --no-judge --json bench.json`,
+	RunE: runBench,
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name := args[0]
+	if benchMinPass < 0 || benchMinPass > 100 {
+		return UsageError(fmt.Errorf("--min-pass must be a percentage between 0 and 100"))
+	}
+	targets, err := benchTargetList()
+	if err != nil {
+		return err
+	}
+
+	mgr := skills.NewManager()
+	if !mgr.Exists(name) {
+		return notFoundError(name)
+	}
+	suite, err := loadSuite(mgr, name)
+	if err != nil {
+		return err
+	}
+	vars, err := render.ParseVars(benchVars)
+	if err != nil {
+		return UsageError(err)
+	}
+	skill, err := mgr.Resolve(name)
+	if err != nil {
+		return err
+	}
+	bundle := render.Gather(ctx, skill.Context, render.GatherOptions{Exec: benchExec})
+
+	judgeRules := !benchNoJudge && len(skill.Rules) > 0
+	judge, err := judgeProvider(llm.GetProvider())
+	if err != nil {
+		return err
+	}
+	if (judgeRules || suiteHasCriteria(suite)) && !judge.IsConfigured() {
+		return fmt.Errorf("no provider configured to judge with; use --judge-provider or 'openskill config set provider'")
+	}
+	var rules []string
+	if judgeRules {
+		rules = eval.ExpandRules(skill, vars)
+	}
+
+	// Latency means nothing for a cached response
+	if llm.Caching == llm.CacheOn {
+		llm.Caching = llm.CacheOff
+	}
+
+	text := !structuredOutput()
+	if text {
+		fmt.Printf("\nBenchmark: %s (%d cases, %d targets, judged by %s)\n", skill.Name, len(suite.Cases), len(targets), providerLabel(judge))
+		fmt.Println("═══════════════════════════════════════════════════")
+		for _, skipped := range bundle.Skipped {
+			fmt.Printf("⚠ Context skipped: %s\n", skipped)
+		}
+	}
+
+	out := BenchOutput{
+		Skill:       skill.Name,
+		Judge:       providerLabel(judge),
+		Cases:       len(suite.Cases),
+		MinPassRate: benchMinPass / 100,
+		Date:        time.Now().Format(time.RFC3339),
+	}
+	failedTargets := 0
+	for _, target := range targets {
+		provider := llm.GetProviderWithModel(target.Provider, target.Model)
+		if target.Model == "" {
+			target.Model = llm.ModelOf(provider)
+		}
+		if !provider.IsConfigured() {
+			failedTargets++
+			out.Targets = append(out.Targets, eval.BenchResult{Target: target, Error: "provider not configured"})
+			if text {
+				fmt.Printf("❌ %s: provider not configured\n", target)
+			}
+			continue
+		}
+
+		if text {
+			fmt.Printf("Running on %s...\n", target)
+		}
+		runner := &eval.Runner{
+			Skill:      skill,
+			Context:    bundle,
+			Vars:       vars,
+			Provider:   provider,
+			Judge:      judge,
+			Parallel:   benchParallel,
+			JudgeRules: judgeRules,
+		}
+		results := runner.Run(usage.WithSkill(ctx, name), suite)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result := eval.NewBenchResult(target, results, rules)
+		if result.Errors == result.Cases {
+			failedTargets++
+		}
+		out.Targets = append(out.Targets, result)
+	}
+	if best := eval.Cheapest(out.Targets, out.MinPassRate); best >= 0 {
+		out.Recommended = out.Targets[best].Target.String()
+	}
+
+	if benchJSON != "" {
+		data, err := benchJSONReport(out)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(benchJSON, data, 0644); err != nil {
+			return fmt.Errorf("failed to write JSON report: %w", err)
+		}
+	}
+	if benchMarkdown != "" {
+		if err := os.WriteFile(benchMarkdown, []byte(benchMarkdownReport(out)), 0644); err != nil {
+			return fmt.Errorf("failed to write Markdown report: %w", err)
+		}
+	}
+
+	if structuredOutput() {
+		if err := printStructured(out); err != nil {
+			return err
+		}
+	} else {
+		printBenchTable(out)
+		for _, path := range []string{benchJSON, benchMarkdown} {
+			if path != "" {
+				fmt.Printf("  Report written to %s\n", path)
+			}
+		}
+		fmt.Println()
+	}
+
+	if failedTargets == len(targets) {
+		return reportedError(fmt.Errorf("no target could run the suite"))
+	}
+	return nil
+}
+
+// benchTargetList parses --target, or lists every configured provider
+func benchTargetList() ([]eval.Target, error) {
+	specs := benchTargets
+	if len(specs) == 0 {
+		specs = llm.GetAvailableProviders()
+		if len(specs) == 0 {
+			return nil, fmt.Errorf("no AI provider configured. Use 'openskill config set api-key' or pass --target")
+		}
+	}
+	var targets []eval.Target
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		target, err := eval.ParseTarget(spec)
+		if err != nil {
+			return nil, UsageError(err)
+		}
+		if _, ok := llm.LookupProvider(target.Provider); !ok {
+			return nil, UsageError(fmt.Errorf("unknown provider: %s (valid: %s)", target.Provider, strings.Join(llm.ProviderNames(), ", ")))
+		}
+		if seen[target.String()] {
+			continue
+		}
+		seen[target.String()] = true
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func suiteHasCriteria(suite *eval.Suite) bool {
+	for _, c := range suite.Cases {
+		if len(c.Expect.Criteria) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// printBenchTable prints one row per target, marking those that reach the
+// pass bar
+func printBenchTable(out BenchOutput) {
+	fmt.Println("───────────────────────────────────────────────────")
+	fmt.Printf("  %-32s %-6s %-6s %-8s %-8s %-13s %s\n", "Target", "Pass", "Rules", "Median", "p95", "Tokens", "Cost")
+	for _, r := range out.Targets {
+		label := truncate(r.Target.String(), 32)
+		if r.Error != "" {
+			fmt.Printf("❌ %-32s %s\n", label, r.Error)
+			continue
+		}
+		glyph := "✓"
+		if r.PassRate < out.MinPassRate {
+			glyph = "❌"
+		}
+		fmt.Printf("%s %-32s %-6s %-6s %-8s %-8s %-13s %s\n", glyph, label,
+			percent(r.PassRate), compliancePercent(r.Compliance),
+			fmt.Sprintf("%dms", r.MedianMS), fmt.Sprintf("%dms", r.P95MS),
+			fmt.Sprintf("%d/%d", r.PromptTokens, r.CompletionTokens), benchCost(r))
+	}
+	fmt.Println("───────────────────────────────────────────────────")
+	bar := percent(out.MinPassRate)
+	if out.Recommended != "" {
+		fmt.Printf("  Cheapest reaching %s pass rate: %s\n", bar, out.Recommended)
+	} else {
+		fmt.Printf("  No target reached a %s pass rate\n", bar)
+	}
+}
+
+// benchJSONReport formats the JSON report without escaping <, > and &
+func benchJSONReport(out BenchOutput) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// benchMarkdownReport formats the comparison as a Markdown document
+func benchMarkdownReport(out BenchOutput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Benchmark: %s\n\n", out.Skill)
+	fmt.Fprintf(&sb, "%d test cases, judged by %s, %s.\n\n", out.Cases, out.Judge, out.Date)
+	sb.WriteString("| Target | Pass rate | Rule compliance | Median latency | p95 latency | Tokens (in/out) | Cost |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, r := range out.Targets {
+		if r.Error != "" {
+			fmt.Fprintf(&sb, "| `%s` | error: %s | | | | | |\n", r.Target, r.Error)
+			continue
+		}
+		fmt.Fprintf(&sb, "| `%s` | %s (%d/%d) | %s | %dms | %dms | %d/%d | %s |\n",
+			r.Target, percent(r.PassRate), r.Passed, r.Cases, compliancePercent(r.Compliance),
+			r.MedianMS, r.P95MS, r.PromptTokens, r.CompletionTokens, benchCost(r))
+	}
+
+	bar := percent(out.MinPassRate)
+	if out.Recommended != "" {
+		fmt.Fprintf(&sb, "\n**Recommended:** `%s`, the cheapest target with at least a %s pass rate.\n", out.Recommended, bar)
+	} else {
+		fmt.Fprintf(&sb, "\nNo target reached a %s pass rate.\n", bar)
+	}
+
+	var failures strings.Builder
+	for _, r := range out.Targets {
+		for _, c := range r.Results {
+			if c.Pass {
+				continue
+			}
+			reason := c.Error
+			for _, check := range c.Checks {
+				if reason == "" && !check.Pass {
+					reason = check.Check
+				}
+			}
+			fmt.Fprintf(&failures, "- `%s` %s: %s\n", r.Target, c.Name, reason)
+		}
+	}
+	if failures.Len() > 0 {
+		sb.WriteString("\n## Failed cases\n\n")
+		sb.WriteString(failures.String())
+	}
+	return sb.String()
+}
+
+// compliancePercent formats an optional compliance score
+func compliancePercent(score *float64) string {
+	if score == nil {
+		return "n/a"
+	}
+	return percent(*score)
+}
+
+// benchCost formats a target's cost, or "n/a" when its price is unknown
+func benchCost(r eval.BenchResult) string {
+	if !r.Priced {
+		return "n/a"
+	}
+	return fmt.Sprintf("$%.4f", r.Cost)
+}
+
+func init() {
+	BenchCmd.Flags().StringSliceVar(&benchTargets, "target", nil, "Provider or provider:model to run (repeatable; default: every configured provider)")
+	BenchCmd.Flags().StringArrayVar(&benchVars, "var", nil, "Set a skill variable (name=value, repeatable)")
+	BenchCmd.Flags().BoolVar(&benchExec, "exec", false, "Run the skill's context commands and fetch its context URLs")
+	BenchCmd.Flags().IntVar(&benchParallel, "parallel", eval.DefaultParallel, "Cases to run at once for each target")
+	BenchCmd.Flags().BoolVar(&benchNoJudge, "no-judge", false, "Skip judging rule compliance (criteria in tests.yaml are still judged)")
+	BenchCmd.Flags().Float64Var(&benchMinPass, "min-pass", 100, "Pass rate, in percent, a target needs to be recommended")
+	BenchCmd.Flags().StringVar(&benchJSON, "json", "", "Write a JSON report to this file")
+	BenchCmd.Flags().StringVar(&benchMarkdown, "markdown", "", "Write a Markdown report to this file")
+	addJudgeFlags(BenchCmd)
+}
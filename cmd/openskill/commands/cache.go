@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"openskill/pkg/llm"
+
+	"github.com/spf13/cobra"
+)
+
+// NoCache and CacheOnly are set by the global --no-cache and --cache-only flags
+var (
+	NoCache   bool
+	CacheOnly bool
+)
+
+// CheckCacheFlags applies --no-cache / --cache-only to AI providers and
+// checks the record/replay environment
+func CheckCacheFlags() error {
+	if os.Getenv(llm.RecordEnv) != "" && os.Getenv(llm.ReplayEnv) != "" {
+		return UsageError(fmt.Errorf("%s and %s cannot both be set", llm.RecordEnv, llm.ReplayEnv))
+	}
+
+	switch {
+	case NoCache && CacheOnly:
+		return UsageError(fmt.Errorf("--no-cache and --cache-only cannot be used together"))
+	case NoCache:
+		llm.Caching = llm.CacheOff
+	case CacheOnly:
+		llm.Caching = llm.CacheOnly
+	}
+	return nil
+}
+
+var cacheExpired bool
+
+var CacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the AI response cache",
+	Long: `AI responses are cached under ~/.openskill/cache, keyed by provider,
+model, messages and options, so repeating an explain or test doesn't pay
+for the same completion twice.
+
+Entries expire after cache_ttl (default 24h) and the least recently used
+are evicted beyond cache_max_mb (default 50). Set cache_ttl to "0" in
+~/.openskill/config.yaml to turn the cache off.
+
+Per command:
+  --no-cache      Always call the provider
+  --cache-only    Never call the provider; fail when nothing is cached`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache size and age",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := llm.OpenCache()
+		if err != nil {
+			return err
+		}
+		stats, err := cache.Stats()
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			out := CacheStatsOutput{
+				Dir:      cache.Dir,
+				Enabled:  cache.Enabled(),
+				TTL:      cache.TTL.String(),
+				MaxBytes: cache.MaxBytes,
+				Entries:  stats.Entries,
+				Expired:  stats.Expired,
+				Bytes:    stats.Bytes,
+			}
+			if !stats.Oldest.IsZero() {
+				out.Oldest = &stats.Oldest
+				out.Newest = &stats.Newest
+			}
+			return printStructured(out)
+		}
+
+		fmt.Println()
+		fmt.Println("  Response Cache")
+		fmt.Println("  ═══════════════════════════════════════════════════════")
+		fmt.Println()
+		fmt.Printf("  Directory:   %s\n", cache.Dir)
+		if cache.Enabled() {
+			fmt.Printf("  TTL:         %s\n", cache.TTL)
+		} else {
+			fmt.Println("  TTL:         disabled (cache_ttl is 0)")
+		}
+		fmt.Printf("  Size:        %s of %s\n", formatBytes(stats.Bytes), formatBytes(cache.MaxBytes))
+		fmt.Printf("  Entries:     %d", stats.Entries)
+		if stats.Expired > 0 {
+			fmt.Printf(" (%d expired)", stats.Expired)
+		}
+		fmt.Println()
+		if stats.Entries > 0 {
+			fmt.Printf("  Oldest:      %s\n", stats.Oldest.Format("2006-01-02 15:04"))
+			fmt.Printf("  Newest:      %s\n", stats.Newest.Format("2006-01-02 15:04"))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete cached responses",
+	Args:  cobra.NoArgs,
+	Example: `  openskill cache clear
+  openskill cache clear --expired`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := llm.OpenCache()
+		if err != nil {
+			return err
+		}
+
+		var removed int
+		if cacheExpired {
+			removed, err = cache.Prune()
+		} else {
+			removed, err = cache.Clear()
+		}
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return printStructured(CacheClearOutput{Removed: removed, ExpiredOnly: cacheExpired})
+		}
+		fmt.Printf("✓ Removed %d cached response(s)\n", removed)
+		return nil
+	},
+}
+
+// formatBytes renders a size for humans
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func init() {
+	cacheClearCmd.Flags().BoolVar(&cacheExpired, "expired", false, "Only remove expired entries and trim to the size limit")
+
+	CacheCmd.AddCommand(cacheStatsCmd)
+	CacheCmd.AddCommand(cacheClearCmd)
+}
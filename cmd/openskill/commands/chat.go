@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"openskill/pkg/core"
+	"openskill/pkg/llm"
+	"openskill/pkg/skills"
+	"openskill/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+// chatHelp lists the slash commands available in a chat session
+const chatHelp = `  /reset              Start the conversation over
+  /save <file>        Write the transcript as Markdown
+  /switch <skill>     Continue with another skill's system prompt
+  /model [name]       Show the provider and model, or switch model
+  /rule add <text>    Add a rule to the skill (saving a version first)
+  /rules              Show the skill's resolved rules
+  /help               Show these commands
+  /exit               Leave (Ctrl-D also works)`
+
+var chatVars []string
+var chatExec bool
+
+var ChatCmd = &cobra.Command{
+	Use:   "chat <skill-name>",
+	Short: "Chat interactively with a skill",
+	Long: `Start an interactive conversation with the AI provider, using the
+skill (composed with its extends and includes) as the system prompt and
+its examples as sample turns. Replies stream as they are generated and
+the whole conversation is sent with each turn.
+
+Commands:
+` + chatHelp,
+	Args:    cobra.ExactArgs(1),
+	Example: `  openskill chat code-review`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := &chatSession{mgr: skills.NewManager()}
+		if err := s.load(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		s.provider = llm.GetProvider()
+		if !s.provider.IsConfigured() {
+			return fmt.Errorf("no AI provider configured. Use 'openskill config set api-key'")
+		}
+
+		fmt.Printf("\nChatting with skill '%s' using %s (%s)\n", s.skill.Name, s.provider.Name(), displayModel(llm.ModelOf(s.provider)))
+		fmt.Println("Type /help for commands, /exit to leave.")
+		fmt.Println("═══════════════════════════════════════════════════")
+
+		return s.run(cmd.Context())
+	},
+}
+
+// chatSession is the state of one `chat` REPL
+type chatSession struct {
+	mgr      *skills.Manager
+	name     string      // Skill as named by the user
+	skill    *core.Skill // Resolved skill
+	system   string
+	examples []llm.Message // Few-shot turns sent before the conversation
+	provider llm.Provider
+	history  []llm.Message
+
+	transcript strings.Builder
+}
+
+// load compiles a skill into the system prompt and example turns
+func (s *chatSession) load(ctx context.Context, name string) error {
+	skill, req, bundle, err := compileSkill(ctx, name, chatVars, chatExec, "")
+	if err != nil {
+		return err
+	}
+	for _, skipped := range bundle.Skipped {
+		fmt.Printf("⚠ Context skipped: %s\n", skipped)
+	}
+	s.name = name
+	s.skill = skill
+	s.system = req.System
+	s.examples = req.Messages
+	return nil
+}
+
+func (s *chatSession) run(ctx context.Context) error {
+	s.note("Skill: %s", s.skill.Name)
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("\n> ")
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Println()
+			return nil // EOF
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "/") {
+			done, err := s.command(ctx, line)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+			}
+			if done {
+				return nil
+			}
+			continue
+		}
+
+		if err := s.send(ctx, line); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			fmt.Printf("❌ %v\n", err)
+		}
+	}
+}
+
+// send adds a user turn and streams the reply
+func (s *chatSession) send(ctx context.Context, text string) error {
+	messages := append(append([]llm.Message{}, s.history...), llm.Message{Role: llm.RoleUser, Content: text})
+	fmt.Println()
+	resp, err := llm.ChatStream(usage.WithSkill(ctx, s.name), s.provider, llm.Request{
+		System:   s.system,
+		Messages: append(append([]llm.Message{}, s.examples...), messages...),
+	}, streamPrinter())
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	s.history = append(messages, llm.Message{Role: llm.RoleAssistant, Content: resp.Content})
+	served := resp.Provider
+	if served == "" {
+		served = s.provider.Name()
+	}
+	fmt.Fprintf(&s.transcript, "## You\n\n%s\n\n## Assistant (%s)\n\n%s\n\n", text, served, strings.TrimSpace(resp.Content))
+	return nil
+}
+
+// command runs a slash command, reporting whether the session should end
+func (s *chatSession) command(ctx context.Context, line string) (bool, error) {
+	fields := strings.Fields(line)
+	arg := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+
+	switch fields[0] {
+	case "/exit", "/quit":
+		return true, nil
+
+	case "/help":
+		fmt.Println(chatHelp)
+
+	case "/reset":
+		s.history = nil
+		s.note("Conversation reset")
+		fmt.Println("✓ Conversation reset")
+
+	case "/save":
+		if arg == "" {
+			return false, fmt.Errorf("usage: /save <file>")
+		}
+		header := fmt.Sprintf("# Chat: %s\n\n_%s_\n\n", s.skill.Name, time.Now().Format("2006-01-02 15:04"))
+		if err := os.WriteFile(arg, []byte(header+s.transcript.String()), 0644); err != nil {
+			return false, fmt.Errorf("failed to save transcript: %w", err)
+		}
+		fmt.Printf("✓ Saved transcript to %s\n", arg)
+
+	case "/switch":
+		if arg == "" {
+			return false, fmt.Errorf("usage: /switch <skill>")
+		}
+		if err := s.load(ctx, arg); err != nil {
+			return false, err
+		}
+		s.note("Switched to skill: %s", s.skill.Name)
+		fmt.Printf("✓ Now using skill '%s' (conversation kept; /reset to start over)\n", s.skill.Name)
+
+	case "/model":
+		if arg == "" {
+			fmt.Printf("  Provider:  %s\n", s.provider.Name())
+			fmt.Printf("  Model:     %s\n", displayModel(llm.ModelOf(s.provider)))
+			return false, nil
+		}
+		// A model name belongs to one provider, so the session leaves any
+		// fallback chain and stays on the provider currently in use
+		name := s.provider.Name()
+		if fb, ok := s.provider.(*llm.FallbackProvider); ok && fb.Served() != "" {
+			name = fb.Served()
+		}
+		s.provider = llm.GetProviderWithModel(name, arg)
+		s.note("Switched to model: %s", arg)
+		fmt.Printf("✓ Now using %s (%s)\n", s.provider.Name(), arg)
+
+	case "/rule":
+		if len(fields) < 3 || fields[1] != "add" {
+			return false, fmt.Errorf("usage: /rule add <text>")
+		}
+		return false, s.addRule(ctx, strings.TrimSpace(strings.TrimPrefix(arg, "add")))
+
+	case "/rules":
+		for i, rule := range s.skill.Rules {
+			fmt.Printf("  %d. %s\n", i+1, rule)
+		}
+
+	default:
+		return false, fmt.Errorf("unknown command %s (type /help)", fields[0])
+	}
+	return false, nil
+}
+
+// addRule appends a rule to the skill on disk, keeping the old version in
+// history, and reloads the system prompt
+func (s *chatSession) addRule(ctx context.Context, rule string) error {
+	skill, err := s.mgr.Get(s.name)
+	if err != nil {
+		return err
+	}
+	if err := s.mgr.SaveVersion(s.name); err != nil {
+		fmt.Printf("Warning: Could not save version history: %v\n", err)
+	}
+	skill.Rules = append(skill.Rules, rule)
+	if err := s.mgr.Edit(s.name, skill); err != nil {
+		return fmt.Errorf("failed to add rule: %w", err)
+	}
+	if err := s.load(ctx, s.name); err != nil {
+		return err
+	}
+	s.note("Added rule: %s", rule)
+	fmt.Printf("✓ Added rule %d to '%s'\n", len(skill.Rules), skill.Name)
+	return nil
+}
+
+// note records a session event in the transcript
+func (s *chatSession) note(format string, args ...interface{}) {
+	fmt.Fprintf(&s.transcript, "_"+format+"_\n\n", args...)
+}
+
+func init() {
+	ChatCmd.Flags().StringArrayVar(&chatVars, "var", nil, "Set a skill variable (name=value, repeatable)")
+	ChatCmd.Flags().BoolVar(&chatExec, "exec", false, "Run the skill's context commands and fetch its context URLs")
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"openskill/pkg/config"
 	"openskill/pkg/llm"
@@ -17,13 +19,26 @@ var ConfigCmd = &cobra.Command{
 	Short: "Manage OpenSkill configuration",
 }
 
+var configSetForce bool
+
+// configKeys are the keys accepted by `config set` and `config get`
+var configKeys = []string{
+	"provider", "providers",
+	"api-key", "groq-api-key", "openai-api-key", "anthropic-api-key",
+	"model", "groq-model", "openai-model", "anthropic-model", "ollama-model",
+	"ollama-endpoint", "max-retries", "retry-max-wait",
+}
+
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> [value]",
 	Short: "Set a configuration value",
 	Long: `Set a configuration value. Supported keys:
 
 Provider Selection:
-  provider           Active AI provider (groq, openai, anthropic, ollama)
+  provider           Active AI provider (groq, openai, anthropic, ollama, or a
+                     name from custom_providers or plugins)
+  providers          Comma-separated fallback chain tried in order, e.g.
+                     groq,anthropic,ollama (empty clears it)
 
 API Keys:
   api-key            API key for current provider
@@ -31,18 +46,36 @@ API Keys:
   openai-api-key     OpenAI API key
   anthropic-api-key  Anthropic API key
 
-Models:
+Models (checked against 'openskill models'; --force skips the check):
   model              Default model for all providers
-  groq-model         Groq-specific model (default: llama-3.3-70b-versatile)
-  openai-model       OpenAI-specific model (default: gpt-4o-mini)
-  anthropic-model    Anthropic-specific model (default: claude-3-5-sonnet-20241022)
-  ollama-model       Ollama-specific model (default: llama3.2)
+  groq-model         Groq-specific model (default: ` + config.DefaultModels["groq"] + `)
+  openai-model       OpenAI-specific model (default: ` + config.DefaultModels["openai"] + `)
+  anthropic-model    Anthropic-specific model (default: ` + config.DefaultModels["anthropic"] + `)
+  ollama-model       Ollama-specific model (default: ` + config.DefaultModels["ollama"] + `)
 
 Ollama:
   ollama-endpoint    Custom Ollama endpoint (default: http://localhost:11434)
 
+Retries:
+  max-retries        Retries for rate-limited or failed requests (default: 3, 0 disables)
+  retry-max-wait     Total time to wait across retries (default: 60s)
+
 If value is not provided, you will be prompted to enter it (useful for secrets).`,
 	Args: cobra.RangeArgs(1, 2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return configKeys, cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			if args[0] == "provider" {
+				return llm.ProviderNames(), cobra.ShellCompDirectiveNoFileComp
+			}
+			if provider, ok := modelKeyProvider(args[0]); ok {
+				return modelsForCompletion(provider), cobra.ShellCompDirectiveNoFileComp
+			}
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		var value string
@@ -64,9 +97,15 @@ If value is not provided, you will be prompted to enter it (useful for secrets).
 			return err
 		}
 
+		if provider, ok := modelKeyProvider(key); ok && !configSetForce {
+			if err := checkModel(cmd.Context(), provider, value); err != nil {
+				return err
+			}
+		}
+
 		switch key {
 		case "provider":
-			validProviders := []string{"groq", "openai", "anthropic", "ollama"}
+			validProviders := llm.ProviderNames()
 			value = strings.ToLower(value)
 			valid := false
 			for _, p := range validProviders {
@@ -80,6 +119,13 @@ If value is not provided, you will be prompted to enter it (useful for secrets).
 			}
 			cfg.Provider = value
 
+		case "providers":
+			chain, err := parseProviderChain(value)
+			if err != nil {
+				return err
+			}
+			cfg.Providers = chain
+
 		case "api-key":
 			provider := config.GetProvider()
 			switch provider {
@@ -90,7 +136,12 @@ If value is not provided, you will be prompted to enter it (useful for secrets).
 			case "anthropic":
 				cfg.AnthropicAPIKey = value
 			default:
-				cfg.GroqAPIKey = value
+				if cp, ok := cfg.CustomProviders[provider]; ok {
+					cp.APIKey = value
+					cfg.CustomProviders[provider] = cp
+				} else {
+					cfg.GroqAPIKey = value
+				}
 			}
 		case "groq-api-key":
 			cfg.GroqAPIKey = value
@@ -113,6 +164,18 @@ If value is not provided, you will be prompted to enter it (useful for secrets).
 		case "ollama-endpoint":
 			cfg.OllamaEndpoint = value
 
+		case "max-retries":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return UsageError(fmt.Errorf("max-retries must be a whole number of 0 or more, got %q", value))
+			}
+			cfg.MaxRetries = &n
+		case "retry-max-wait":
+			if _, err := time.ParseDuration(value); err != nil {
+				return UsageError(fmt.Errorf("retry-max-wait must be a duration such as 30s or 2m, got %q", value))
+			}
+			cfg.RetryMaxWait = value
+
 		default:
 			return fmt.Errorf("unknown config key: %s\nRun 'openskill config set --help' for available keys", key)
 		}
@@ -127,9 +190,10 @@ If value is not provided, you will be prompted to enter it (useful for secrets).
 }
 
 var configGetCmd = &cobra.Command{
-	Use:   "get <key>",
-	Short: "Get a configuration value",
-	Args:  cobra.ExactArgs(1),
+	Use:       "get <key>",
+	Short:     "Get a configuration value",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: configKeys,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 
@@ -141,6 +205,8 @@ var configGetCmd = &cobra.Command{
 		switch key {
 		case "provider":
 			fmt.Println(config.GetProvider())
+		case "providers":
+			fmt.Println(strings.Join(cfg.Providers, ","))
 
 		case "api-key":
 			apiKey := config.GetAPIKey()
@@ -182,6 +248,11 @@ var configGetCmd = &cobra.Command{
 		case "ollama-endpoint":
 			fmt.Println(config.GetOllamaEndpoint())
 
+		case "max-retries":
+			fmt.Println(llm.ConfiguredRetryPolicy().MaxRetries)
+		case "retry-max-wait":
+			fmt.Println(llm.ConfiguredRetryPolicy().MaxWait)
+
 		default:
 			return fmt.Errorf("unknown config key: %s", key)
 		}
@@ -199,6 +270,32 @@ var configListCmd = &cobra.Command{
 			return err
 		}
 
+		retries := llm.ConfiguredRetryPolicy()
+		if structuredOutput() {
+			return printStructured(ConfigOutput{
+				Provider:  config.GetProvider(),
+				Providers: cfg.Providers,
+				APIKeys: map[string]string{
+					"groq":      maskOptional(cfg.GroqAPIKey),
+					"openai":    maskOptional(cfg.OpenAIAPIKey),
+					"anthropic": maskOptional(cfg.AnthropicAPIKey),
+				},
+				Models: map[string]string{
+					"groq":      config.GetProviderModel("groq"),
+					"openai":    config.GetProviderModel("openai"),
+					"anthropic": config.GetProviderModel("anthropic"),
+					"ollama":    config.GetProviderModel("ollama"),
+				},
+				OllamaEndpoint:  config.GetOllamaEndpoint(),
+				CustomProviders: customProviderURLs(cfg),
+				Plugins:         pluginCommands(cfg),
+				MaxRetries:      retries.MaxRetries,
+				RetryMaxWait:    retries.MaxWait.String(),
+				Configured:      llm.GetAvailableProviders(),
+				ConfigFile:      "~/.openskill/config.yaml",
+			})
+		}
+
 		fmt.Println()
 		fmt.Println("  OpenSkill Configuration")
 		fmt.Println("  ═══════════════════════════════════════════════════════")
@@ -206,6 +303,9 @@ var configListCmd = &cobra.Command{
 
 		provider := config.GetProvider()
 		fmt.Printf("  Provider:          %s\n", provider)
+		if len(cfg.Providers) > 0 {
+			fmt.Printf("  Fallback chain:    %s\n", strings.Join(cfg.Providers, " → "))
+		}
 		fmt.Println()
 
 		fmt.Println("  API Keys:")
@@ -225,6 +325,32 @@ var configListCmd = &cobra.Command{
 		fmt.Printf("    Endpoint:        %s\n", config.GetOllamaEndpoint())
 		fmt.Println()
 
+		if len(cfg.CustomProviders) > 0 {
+			fmt.Println("  Custom providers:")
+			for _, name := range config.GetCustomProviderNames() {
+				fmt.Printf("    %-17s%s (model: %s)\n", name+":", cfg.CustomProviders[name].BaseURL, displayModel(config.GetProviderModel(name)))
+			}
+			fmt.Println()
+		}
+
+		if len(cfg.Plugins) > 0 {
+			fmt.Println("  Plugins:")
+			for _, name := range config.GetPluginNames() {
+				client := llm.NewPluginClient(name, cfg.Plugins[name])
+				status := "✓"
+				if !client.IsConfigured() {
+					status = "❌ not found"
+				}
+				fmt.Printf("    %-17s%s %s\n", name+":", llm.PluginCommand(name, cfg.Plugins[name]), status)
+			}
+			fmt.Println()
+		}
+
+		fmt.Println("  Retries:")
+		fmt.Printf("    Max retries:     %d\n", retries.MaxRetries)
+		fmt.Printf("    Max wait:        %s\n", retries.MaxWait)
+		fmt.Println()
+
 		available := llm.GetAvailableProviders()
 		fmt.Printf("  Configured:        %s\n", strings.Join(available, ", "))
 		fmt.Println()
@@ -233,13 +359,58 @@ var configListCmd = &cobra.Command{
 		fmt.Println()
 		fmt.Println("  Environment variables (take precedence):")
 		fmt.Println("    OPENSKILL_PROVIDER, GROQ_API_KEY, OPENAI_API_KEY,")
-		fmt.Println("    ANTHROPIC_API_KEY, OPENSKILL_MODEL, OLLAMA_HOST")
+		fmt.Println("    ANTHROPIC_API_KEY, OPENSKILL_MODEL, OLLAMA_HOST,")
+		fmt.Println("    OPENSKILL_MAX_RETRIES")
 		fmt.Println()
 
 		return nil
 	},
 }
 
+// parseProviderChain splits a comma-separated providers list, checking
+// each name against the registry
+func parseProviderChain(value string) ([]string, error) {
+	valid := llm.ProviderNames()
+	var chain []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := llm.LookupProvider(name); !ok {
+			return nil, UsageError(fmt.Errorf("invalid provider in chain: %s (valid: %s)", name, strings.Join(valid, ", ")))
+		}
+		chain = append(chain, name)
+	}
+	return chain, nil
+}
+
+// customProviderURLs maps each custom provider to its base URL
+func customProviderURLs(cfg *config.Config) map[string]string {
+	urls := map[string]string{}
+	for name, cp := range cfg.CustomProviders {
+		urls[name] = cp.BaseURL
+	}
+	return urls
+}
+
+// pluginCommands maps each provider plugin to its executable
+func pluginCommands(cfg *config.Config) map[string]string {
+	commands := map[string]string{}
+	for name, p := range cfg.Plugins {
+		commands[name] = llm.PluginCommand(name, p)
+	}
+	return commands
+}
+
+// displayModel shows an empty model as the server's default
+func displayModel(model string) string {
+	if model == "" {
+		return "server default"
+	}
+	return model
+}
+
 func maskKey(key string) string {
 	if len(key) <= 8 {
 		return "***"
@@ -247,6 +418,14 @@ func maskKey(key string) string {
 	return key[:4] + "..." + key[len(key)-4:]
 }
 
+// maskOptional masks a secret, returning "" when it is not set
+func maskOptional(key string) string {
+	if key == "" {
+		return ""
+	}
+	return maskKey(key)
+}
+
 func printConfigValue(label, value string, isSecret bool) {
 	if value == "" {
 		fmt.Printf("%s:       (not set)\n", label)
@@ -258,6 +437,8 @@ func printConfigValue(label, value string, isSecret bool) {
 }
 
 func init() {
+	configSetCmd.Flags().BoolVar(&configSetForce, "force", false, "Set a model even if the provider doesn't list it")
+
 	ConfigCmd.AddCommand(configSetCmd)
 	ConfigCmd.AddCommand(configGetCmd)
 	ConfigCmd.AddCommand(configListCmd)
@@ -29,7 +29,7 @@ Use --v1 and --v2 flags to compare specific versions.`,
 
 		// Check skill exists
 		if _, err := mgr.Get(name); err != nil {
-			return fmt.Errorf("skill '%s' not found", name)
+			return notFoundError(name)
 		}
 
 		// Get versions
@@ -39,6 +39,9 @@ Use --v1 and --v2 flags to compare specific versions.`,
 		}
 
 		if len(versions) == 0 && diffVersion1 == 0 && diffVersion2 == 0 {
+			if structuredOutput() {
+				return printStructured(DiffOutput{Skill: name, From: "current", To: "current", Identical: true, Changes: []DiffLine{}})
+			}
 			fmt.Println("No version history available for this skill.")
 			fmt.Println("Use 'openskill edit' to create versions.")
 			return nil
@@ -67,38 +70,24 @@ Use --v1 and --v2 flags to compare specific versions.`,
 			label2 = fmt.Sprintf("v%d", v2)
 		}
 
-		fmt.Printf("\nComparing %s (%s) with %s (%s)\n", name, label1, name, label2)
-		fmt.Println("═══════════════════════════════════════════════════")
-
-		// Simple line-by-line diff
-		lines1 := strings.Split(content1, "\n")
-		lines2 := strings.Split(content2, "\n")
-
-		// Find differences
-		maxLines := len(lines1)
-		if len(lines2) > maxLines {
-			maxLines = len(lines2)
+		changes := diffLines(content1, content2)
+		hasDiff := len(changes) > 0
+
+		if structuredOutput() {
+			return printStructured(DiffOutput{
+				Skill:     name,
+				From:      label1,
+				To:        label2,
+				Identical: !hasDiff,
+				Changes:   changes,
+			})
 		}
 
-		hasDiff := false
-		for i := 0; i < maxLines; i++ {
-			var l1, l2 string
-			if i < len(lines1) {
-				l1 = lines1[i]
-			}
-			if i < len(lines2) {
-				l2 = lines2[i]
-			}
+		fmt.Printf("\nComparing %s (%s) with %s (%s)\n", name, label1, name, label2)
+		fmt.Println("═══════════════════════════════════════════════════")
 
-			if l1 != l2 {
-				hasDiff = true
-				if l1 != "" && (i >= len(lines2) || l1 != l2) {
-					fmt.Printf("- %s\n", l1)
-				}
-				if l2 != "" && (i >= len(lines1) || l1 != l2) {
-					fmt.Printf("+ %s\n", l2)
-				}
-			}
+		for _, c := range changes {
+			fmt.Printf("%s %s\n", c.Op, c.Text)
 		}
 
 		if !hasDiff {
@@ -110,6 +99,39 @@ Use --v1 and --v2 flags to compare specific versions.`,
 	},
 }
 
+// diffLines performs a simple line-by-line comparison of two files
+func diffLines(content1, content2 string) []DiffLine {
+	lines1 := strings.Split(content1, "\n")
+	lines2 := strings.Split(content2, "\n")
+
+	// Find differences
+	maxLines := len(lines1)
+	if len(lines2) > maxLines {
+		maxLines = len(lines2)
+	}
+
+	changes := []DiffLine{}
+	for i := 0; i < maxLines; i++ {
+		var l1, l2 string
+		if i < len(lines1) {
+			l1 = lines1[i]
+		}
+		if i < len(lines2) {
+			l2 = lines2[i]
+		}
+
+		if l1 != l2 {
+			if l1 != "" {
+				changes = append(changes, DiffLine{Op: "-", Line: i + 1, Text: l1})
+			}
+			if l2 != "" {
+				changes = append(changes, DiffLine{Op: "+", Line: i + 1, Text: l2})
+			}
+		}
+	}
+	return changes
+}
+
 func init() {
 	DiffCmd.Flags().IntVar(&diffVersion1, "v1", 0, "First version to compare (0 = current)")
 	DiffCmd.Flags().IntVar(&diffVersion2, "v2", 0, "Second version to compare (0 = current)")
@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"openskill/pkg/config"
+	"openskill/pkg/llm"
+	"openskill/pkg/skills"
+
+	"github.com/spf13/cobra"
+)
+
+// Check statuses reported by `doctor`
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorTimeout bounds each provider check
+const doctorTimeout = 10 * time.Second
+
+// slowProvider is the latency above which a provider check warns
+const slowProvider = 3 * time.Second
+
+var doctorOffline bool
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the skills directory, config and AI providers",
+	Long: `Diagnose common problems: the skills directory layout, whether every
+SKILL.md parses, config file permissions, which providers have keys, and for
+each provider in use its reachability, latency, API key validity (via a
+cheap authenticated model listing) and whether the configured model exists,
+including whether an Ollama model has been pulled.
+
+Exits with status 1 when any check fails. Use --offline to skip network checks.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var checks []DoctorCheck
+		checks = append(checks, checkSkillsDir()...)
+		checks = append(checks, checkConfigFile()...)
+		checks = append(checks, checkProviders(cmd.Context())...)
+		if cmd.Context().Err() != nil {
+			return cmd.Context().Err()
+		}
+
+		failed := 0
+		warned := 0
+		for _, c := range checks {
+			switch c.Status {
+			case checkFail:
+				failed++
+			case checkWarn:
+				warned++
+			}
+		}
+
+		if structuredOutput() {
+			if err := printStructured(DoctorOutput{Checks: checks, Failed: failed, Warnings: warned}); err != nil {
+				return err
+			}
+			return reportedError(doctorError(failed))
+		}
+
+		fmt.Println()
+		fmt.Println("  OpenSkill Doctor")
+		fmt.Println("  ═══════════════════════════════════════════════════════")
+		fmt.Println()
+		for _, c := range checks {
+			glyph := "✓"
+			switch c.Status {
+			case checkWarn:
+				glyph = "⚠"
+			case checkFail:
+				glyph = "❌"
+			}
+			fmt.Printf("  %s %-4s  %-26s %s\n", glyph, c.Status, c.Name, c.Detail)
+			if c.Hint != "" {
+				fmt.Printf("          %-26s → %s\n", "", c.Hint)
+			}
+		}
+		fmt.Println("  ───────────────────────────────────────────────────────")
+		fmt.Printf("  %d passed, %d warnings, %d failed\n\n", len(checks)-failed-warned, warned, failed)
+
+		return doctorError(failed)
+	},
+}
+
+func doctorError(failed int) error {
+	if failed == 0 {
+		return nil
+	}
+	return newCodedError(CodeChecksFailed, fmt.Errorf("%d doctor check(s) failed", failed))
+}
+
+func check(name, status, detail, hint string) DoctorCheck {
+	return DoctorCheck{Name: name, Status: status, Detail: detail, Hint: hint}
+}
+
+// ============== Skills ==============
+
+func checkSkillsDir() []DoctorCheck {
+	info, err := os.Stat(skills.SkillsDir)
+	switch {
+	case os.IsNotExist(err):
+		return []DoctorCheck{check("Skills directory", checkFail, skills.SkillsDir+" not found", "Run 'openskill init' in your project root")}
+	case err != nil:
+		return []DoctorCheck{check("Skills directory", checkFail, err.Error(), "")}
+	case !info.IsDir():
+		return []DoctorCheck{check("Skills directory", checkFail, skills.SkillsDir+" is not a directory", "Move the file aside and run 'openskill init'")}
+	}
+	checks := []DoctorCheck{check("Skills directory", checkPass, skills.SkillsDir, "")}
+
+	// Folders without a SKILL.md are invisible to Claude and to openskill
+	entries, err := os.ReadDir(skills.SkillsDir)
+	if err == nil {
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(skills.SkillsDir, e.Name(), "SKILL.md")); os.IsNotExist(err) {
+				checks = append(checks, check("Skill "+e.Name(), checkWarn, "no SKILL.md", "Add a SKILL.md or remove the folder"))
+			}
+		}
+	}
+
+	mgr := skills.NewManager()
+	dirs, err := mgr.Dirs()
+	if err != nil {
+		return append(checks, check("Skill files", checkFail, err.Error(), ""))
+	}
+	broken := 0
+	for _, name := range dirs {
+		if _, err := mgr.Get(name); err != nil {
+			broken++
+			checks = append(checks, check("Skill "+name, checkFail, err.Error(), fmt.Sprintf("Run 'openskill validate %s' for details", name)))
+		}
+	}
+	if broken == 0 {
+		checks = append(checks, check("Skill files", checkPass, fmt.Sprintf("%d SKILL.md file(s) parse", len(dirs)), ""))
+	}
+	return checks
+}
+
+// ============== Config ==============
+
+func checkConfigFile() []DoctorCheck {
+	dir, err := config.Dir()
+	if err != nil {
+		return []DoctorCheck{check("Config file", checkFail, err.Error(), "")}
+	}
+	path := filepath.Join(dir, "config.yaml")
+	display := "~/.openskill/config.yaml"
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return []DoctorCheck{check("Config file", checkPass, display+" not created yet; using defaults", "")}
+	}
+	if err != nil {
+		return []DoctorCheck{check("Config file", checkFail, err.Error(), "")}
+	}
+
+	var checks []DoctorCheck
+	if _, err := config.Load(); err != nil {
+		checks = append(checks, check("Config file", checkFail, err.Error(), "Fix the YAML in "+display))
+	} else {
+		checks = append(checks, check("Config file", checkPass, display, ""))
+	}
+
+	if mode := info.Mode().Perm(); mode&0077 != 0 {
+		checks = append(checks, check("Config permissions", checkWarn,
+			fmt.Sprintf("%s is %04o; API keys are readable by other users", display, mode),
+			"chmod 600 "+display))
+	} else {
+		checks = append(checks, check("Config permissions", checkPass, fmt.Sprintf("%04o", mode), ""))
+	}
+	return checks
+}
+
+// ============== Providers ==============
+
+func checkProviders(ctx context.Context) []DoctorCheck {
+	inUse := map[string]bool{}
+	for _, name := range config.GetProviderChain() {
+		inUse[name] = true
+	}
+
+	var checks []DoctorCheck
+	var configured []string
+	for _, info := range llm.Providers() {
+		p := info.New()
+		name := "Provider " + info.Name
+		switch {
+		case p.IsConfigured():
+			detail := "configured"
+			if inUse[info.Name] {
+				detail += " (in use)"
+			}
+			checks = append(checks, check(name, checkPass, detail, ""))
+			configured = append(configured, info.Name)
+		case inUse[info.Name]:
+			checks = append(checks, check(name, checkFail, "in use but not configured", keyHint(info.Name)))
+		}
+	}
+	if len(configured) == 0 {
+		checks = append(checks, check("Providers", checkFail, "no AI provider is configured", "Run 'openskill config set api-key' or set GROQ_API_KEY"))
+	}
+
+	if doctorOffline {
+		return checks
+	}
+	for _, name := range configured {
+		// Ollama always counts as configured; only probe it when it's used
+		if name == string(llm.ProviderOllama) && !inUse[name] {
+			continue
+		}
+		checks = append(checks, probeProvider(ctx, name, inUse[name])...)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return checks
+}
+
+// probeProvider makes a cheap authenticated call to check reachability,
+// latency, the API key and the configured model. Problems with providers
+// that aren't in use only warn.
+func probeProvider(ctx context.Context, name string, inUse bool) []DoctorCheck {
+	info, _ := llm.LookupProvider(name)
+	p := info.New()
+	label := "Reach " + name
+	failStatus := checkFail
+	if !inUse {
+		failStatus = checkWarn
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	start := time.Now()
+
+	var models []string
+	var err error
+	if hc, ok := llm.Unwrap(p).(llm.HealthChecker); ok {
+		err = hc.Health(ctx)
+	} else if _, ok := llm.Unwrap(p).(llm.ModelLister); ok {
+		models, err = llm.ListModels(ctx, p)
+	} else {
+		return []DoctorCheck{check(label, checkPass, "no health check available; skipped", "")}
+	}
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		hint := ""
+		var apiErr *llm.APIError
+		if errors.As(err, &apiErr) {
+			hint = apiErr.Hint()
+			if apiErr.Kind == llm.ErrAuth {
+				return []DoctorCheck{check("Auth "+name, failStatus, "API key rejected: "+err.Error(), hint)}
+			}
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return []DoctorCheck{check(label, failStatus, fmt.Sprintf("no answer within %s", doctorTimeout), "Check your network or the provider's status page")}
+		}
+		return []DoctorCheck{check(label, failStatus, err.Error(), hint)}
+	}
+
+	checks := []DoctorCheck{}
+	if elapsed > slowProvider {
+		checks = append(checks, check(label, checkWarn, fmt.Sprintf("slow: answered in %s", elapsed), "Consider a fallback chain with 'openskill config set providers'"))
+	} else {
+		checks = append(checks, check(label, checkPass, fmt.Sprintf("answered in %s", elapsed), ""))
+	}
+	if models != nil && name != string(llm.ProviderOllama) {
+		checks = append(checks, check("Auth "+name, checkPass, "API key accepted", ""))
+	}
+
+	model := config.GetProviderModel(name)
+	if models == nil || model == "" {
+		return checks
+	}
+	list := llm.ModelList{Models: models}
+	switch {
+	case list.Contains(model):
+		checks = append(checks, check("Model "+name, checkPass, model, ""))
+	case name == string(llm.ProviderOllama):
+		checks = append(checks, check("Model "+name, failStatus, model+" is not pulled", "Run 'ollama pull "+model+"'"))
+	default:
+		checks = append(checks, check("Model "+name, checkWarn, model+" is not in the provider's model list",
+			fmt.Sprintf("Run 'openskill models %s' and 'openskill config set %s-model <name>'", name, name)))
+	}
+	return checks
+}
+
+// keyHint suggests how to configure a provider
+func keyHint(name string) string {
+	if _, ok := config.GetCustomProvider(name); ok {
+		return "Check base_url under custom_providers in ~/.openskill/config.yaml"
+	}
+	if _, ok := config.GetPlugin(name); ok {
+		return fmt.Sprintf("Install %s%s on your PATH or set its command under plugins", llm.PluginPrefix, name)
+	}
+	return fmt.Sprintf("Run 'openskill config set %s-api-key'", name)
+}
+
+func init() {
+	DoctorCmd.Flags().BoolVar(&doctorOffline, "offline", false, "Skip reachability, auth and model checks")
+}
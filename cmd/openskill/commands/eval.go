@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"openskill/pkg/eval"
+	"openskill/pkg/llm"
+	"openskill/pkg/render"
+	"openskill/pkg/skills"
+	"openskill/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+var compareV1 string
+var compareV2 string
+var comparePrompts string
+var compareVars []string
+var compareExec bool
+var compareParallel int
+var compareSeed int64
+
+var EvalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate skills with a judge model",
+}
+
+var evalCompareCmd = &cobra.Command{
+	Use:   "compare <skill-name>",
+	Short: "Compare two versions of a skill head to head",
+	Long: `Run two versions of a skill over the same prompts and have a judge model
+pick the better response to each.
+
+Versions are numbers from 'openskill history' or "current" for the SKILL.md
+on disk. --v1 defaults to the latest saved version and --v2 to current, so
+by default the command answers "is my edit an improvement?".
+
+Prompts come from --prompts, a YAML list of strings or of mappings with
+input and optionally name, context and vars, or else from the inputs of
+the skill's tests.yaml. Which version the judge sees first is randomized
+per prompt (--seed makes it repeatable) so position bias cancels out.
+
+The result is v2's win rate, counting ties as half, with a 95% Wilson
+confidence interval. Responses bypass the cache, so each run samples both
+versions afresh.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  openskill eval compare code-review --prompts prompts.yaml
+  openskill eval compare code-review --v1 3 --v2 current --prompts prompts.yaml
+  openskill eval compare code-review --v1 2 --v2 4 --judge-provider anthropic`,
+	RunE: runEvalCompare,
+}
+
+func runEvalCompare(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name := args[0]
+	mgr := skills.NewManager()
+	if !mgr.Exists(name) {
+		return notFoundError(name)
+	}
+
+	v2, err := parseVersion(compareV2)
+	if err != nil {
+		return UsageError(err)
+	}
+	v1 := 0
+	if compareV1 != "" {
+		if v1, err = parseVersion(compareV1); err != nil {
+			return UsageError(err)
+		}
+	} else {
+		versions, err := mgr.GetVersions(name)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("skill '%s' has no saved versions to compare; see 'openskill history %s'", name, name)
+		}
+		v1 = versions[0].Version
+	}
+	if v1 == v2 {
+		return UsageError(fmt.Errorf("--v1 and --v2 are both %s", versionLabel(v1)))
+	}
+
+	list, err := comparisonPrompts(mgr, name)
+	if err != nil {
+		return err
+	}
+	vars, err := render.ParseVars(compareVars)
+	if err != nil {
+		return UsageError(err)
+	}
+
+	comparer := &eval.Comparer{Vars: vars, Parallel: compareParallel, Seed: compareSeed}
+	// Context skipped by only one version is labeled with it
+	var skipped []string
+	skippedBy := map[string][]string{}
+	for _, side := range []struct {
+		version int
+		variant *eval.Variant
+	}{{v1, &comparer.V1}, {v2, &comparer.V2}} {
+		skill, err := mgr.ResolveVersion(name, side.version)
+		if err != nil {
+			return err
+		}
+		bundle := render.Gather(ctx, skill.Context, render.GatherOptions{Exec: compareExec})
+		*side.variant = eval.Variant{Skill: skill, Context: bundle}
+		for _, s := range bundle.Skipped {
+			if skippedBy[s] == nil {
+				skipped = append(skipped, s)
+			}
+			skippedBy[s] = append(skippedBy[s], versionLabel(side.version))
+		}
+	}
+
+	// Cached responses would only re-judge the last run's text
+	if llm.Caching == llm.CacheOn {
+		llm.Caching = llm.CacheOff
+	}
+	provider := llm.GetProvider()
+	if !provider.IsConfigured() {
+		return fmt.Errorf("no AI provider configured. Use 'openskill config set api-key'")
+	}
+	comparer.Provider = provider
+	if comparer.Judge, err = judgeProvider(provider); err != nil {
+		return err
+	}
+
+	label1, label2 := versionLabel(v1), versionLabel(v2)
+	if !structuredOutput() {
+		fmt.Printf("\nComparing %s: %s vs %s (%d prompts, %s)\n", name, label1, label2, len(list), provider.Name())
+		fmt.Println("═══════════════════════════════════════════════════")
+		for _, s := range skipped {
+			if by := skippedBy[s]; len(by) == 1 {
+				s += " (" + by[0] + ")"
+			}
+			fmt.Printf("⚠ Context skipped: %s\n", s)
+		}
+	}
+	results := comparer.Compare(usage.WithSkill(ctx, name), list)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	summary := eval.Summarize(results)
+
+	if structuredOutput() {
+		return printStructured(CompareOutput{
+			Skill:    name,
+			V1:       label1,
+			V2:       label2,
+			Provider: providerLabel(provider),
+			Judge:    providerLabel(comparer.Judge),
+			Seed:     comparer.Seed,
+			Results:  results,
+			Summary:  summary,
+		})
+	}
+
+	labels := map[string]string{eval.WinV1: label1, eval.WinV2: label2, eval.Tie: "tie"}
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Printf("❌ %-20s %s\n", r.Name, r.Error)
+			continue
+		}
+		fmt.Printf("  %-20s %-8s %s\n", truncate(r.Name, 20), labels[r.Winner], truncate(r.Reason, 70))
+	}
+	fmt.Println("───────────────────────────────────────────────────")
+	fmt.Printf("%s won %d, %s won %d, %d tied", label2, summary.Wins, label1, summary.Losses, summary.Ties)
+	if summary.Errors > 0 {
+		fmt.Printf(", %d failed", summary.Errors)
+	}
+	fmt.Printf("  (judged by %s, seed %d)\n", providerLabel(comparer.Judge), comparer.Seed)
+
+	if compared := summary.Wins + summary.Losses + summary.Ties; compared > 0 {
+		fmt.Printf("%s win rate: %s (95%% CI %s–%s)\n", label2, percent(summary.Rate), percent(summary.Low), percent(summary.High))
+		switch {
+		case summary.Low > 0.5:
+			fmt.Printf("✓ %s is better than %s\n", label2, label1)
+		case summary.High < 0.5:
+			fmt.Printf("❌ %s is worse than %s\n", label2, label1)
+		default:
+			fmt.Println("⚠ No clear difference; compare more prompts to narrow the interval")
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// comparisonPrompts loads --prompts, or the inputs of the skill's tests.yaml
+func comparisonPrompts(mgr *skills.Manager, name string) ([]eval.Prompt, error) {
+	if comparePrompts != "" {
+		return eval.LoadPrompts(comparePrompts)
+	}
+	suite, err := eval.LoadSuite(mgr.GetSkillDir(name))
+	if errors.Is(err, eval.ErrNoSuite) {
+		return nil, UsageError(fmt.Errorf("--prompts is required: skill '%s' has no %s to take prompts from", name, eval.SuiteFile))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return eval.SuitePrompts(suite), nil
+}
+
+// parseVersion parses "3", "v3" or "current" (0)
+func parseVersion(s string) (int, error) {
+	if strings.EqualFold(s, "current") {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(s, "v"))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version: %s (expected a number like '3' or 'v3', or 'current')", s)
+	}
+	return version, nil
+}
+
+// versionLabel names a version as parseVersion accepts it
+func versionLabel(version int) string {
+	if version == 0 {
+		return "current"
+	}
+	return fmt.Sprintf("v%d", version)
+}
+
+func init() {
+	evalCompareCmd.Flags().StringVar(&compareV1, "v1", "", "Baseline version (default: the latest saved version)")
+	evalCompareCmd.Flags().StringVar(&compareV2, "v2", "current", "Candidate version")
+	evalCompareCmd.Flags().StringVar(&comparePrompts, "prompts", "", "YAML file of prompts (default: the inputs in the skill's tests.yaml)")
+	evalCompareCmd.Flags().StringArrayVar(&compareVars, "var", nil, "Set a skill variable (name=value, repeatable)")
+	evalCompareCmd.Flags().BoolVar(&compareExec, "exec", false, "Run the skill's context commands and fetch its context URLs")
+	evalCompareCmd.Flags().IntVar(&compareParallel, "parallel", eval.DefaultParallel, "Prompts to run at once")
+	evalCompareCmd.Flags().Int64Var(&compareSeed, "seed", 0, "Seed for the order responses are shown to the judge (default: random)")
+	addJudgeFlags(evalCompareCmd)
+
+	EvalCmd.AddCommand(evalCompareCmd)
+}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"fmt"
-	"strings"
 
 	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
 	"openskill/pkg/skills"
+	"openskill/pkg/usage"
 
 	"github.com/spf13/cobra"
 )
@@ -37,46 +38,24 @@ This is helpful for understanding complex skills or onboarding new team members.
 
 		fmt.Printf("Explaining skill '%s' with %s...\n\n", name, gen.ProviderName())
 
-		// Build explanation prompt
-		var rulesText strings.Builder
-		for i, rule := range skill.Rules {
-			rulesText.WriteString(fmt.Sprintf("%d. %s\n", i+1, rule))
-		}
-
-		verboseInstructions := ""
-		if explainVerbose {
-			verboseInstructions = `
-Also include:
-- Example scenarios where this skill would be applied
-- Potential edge cases the skill handles
-- How this skill might interact with other skills`
+		prompt, err := prompts.Render(prompts.Explain, prompts.SkillData{
+			Name:        skill.Name,
+			Description: skill.Description,
+			Rules:       skill.Rules,
+			Verbose:     explainVerbose,
+		})
+		if err != nil {
+			return err
 		}
 
-		prompt := fmt.Sprintf(`Explain this skill in plain language for a developer who hasn't seen it before.
-
-Skill Name: %s
-Description: %s
-
-Rules:
-%s
-
-Write a clear, concise explanation that covers:
-1. What this skill is designed to do (1-2 sentences)
-2. Key behaviors it enforces
-3. What makes it effective
-%s
-
-Use simple language and avoid jargon. Format the response with clear sections.`,
-			skill.Name, skill.Description, rulesText.String(), verboseInstructions)
+		fmt.Printf("Skill: %s\n", skill.Name)
+		fmt.Println("═══════════════════════════════════════════════════")
 
-		response, err := gen.Provider().Generate(prompt)
+		_, err = llm.ChatStream(usage.WithSkill(cmd.Context(), name), gen.Provider(), llm.PromptRequest(prompt), streamPrinter())
+		fmt.Println()
 		if err != nil {
 			return fmt.Errorf("AI explanation failed: %w", err)
 		}
-
-		fmt.Printf("Skill: %s\n", skill.Name)
-		fmt.Println("═══════════════════════════════════════════════════")
-		fmt.Println(strings.TrimSpace(response))
 		fmt.Println()
 
 		return nil
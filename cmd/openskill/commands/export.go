@@ -1,10 +1,16 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"openskill/pkg/config"
+	"openskill/pkg/llm"
 	"openskill/pkg/skills"
 
 	"github.com/spf13/cobra"
@@ -12,22 +18,36 @@ import (
 
 var exportFormat string
 var exportOutput string
+var exportProvider string
+var exportInput string
+var exportVars []string
 
 var ExportCmd = &cobra.Command{
 	Use:   "export <skill-name>",
 	Short: "Export a skill to different formats",
 	Long: `Export a skill to JSON, YAML, or Markdown format.
 
-Useful for sharing skills, backing up, or integrating with other tools.`,
+Useful for sharing skills, backing up, or integrating with other tools.
+
+The prompt format shows the compiled request as JSON in the layout of a
+provider's API (the current provider unless --provider is given): where
+the system prompt goes, the example turns and --input as the user turn.`,
 	Args: cobra.ExactArgs(1),
 	Example: `  openskill export code-review
   openskill export code-review --format json
-  openskill export code-review --format yaml -o skill.yaml`,
+  openskill export code-review --format yaml -o skill.yaml
+  openskill export code-review --format prompt --provider anthropic --input "Review main.go"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		mgr := skills.NewManager()
 
-		content, err := mgr.Export(name, exportFormat)
+		var content string
+		var err error
+		if exportFormat == "prompt" {
+			content, err = exportPrompt(cmd.Context(), name)
+		} else {
+			content, err = mgr.Export(name, exportFormat)
+		}
 		if err != nil {
 			return err
 		}
@@ -53,7 +73,40 @@ Useful for sharing skills, backing up, or integrating with other tools.`,
 	},
 }
 
+// exportPrompt renders a skill as the request body a provider would receive
+func exportPrompt(ctx context.Context, name string) (string, error) {
+	provider := exportProvider
+	if provider == "" {
+		provider = config.GetProvider()
+	}
+	info, ok := llm.LookupProvider(provider)
+	if !ok {
+		return "", UsageError(fmt.Errorf("unknown provider: %s (valid: %s)", provider, strings.Join(llm.ProviderNames(), ", ")))
+	}
+
+	_, req, bundle, err := compileSkill(ctx, name, exportVars, false, exportInput)
+	if err != nil {
+		return "", err
+	}
+	for _, skipped := range bundle.Skipped {
+		fmt.Fprintf(os.Stderr, "⚠ Context skipped: %s\n", skipped)
+	}
+
+	// Keep the <context> tags readable
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(llm.Payload(info.New(), req)); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
 func init() {
-	ExportCmd.Flags().StringVarP(&exportFormat, "format", "f", "yaml", "Export format (json, yaml, md)")
-	ExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (default: stdout)")
+	ExportCmd.Flags().StringVarP(&exportFormat, "format", "f", "yaml", "Export format (json, yaml, md, prompt)")
+	ExportCmd.Flags().StringVar(&exportProvider, "provider", "", "Provider whose request layout the prompt format uses")
+	ExportCmd.Flags().StringVar(&exportInput, "input", "", "User input to include with the prompt format")
+	ExportCmd.Flags().StringArrayVar(&exportVars, "var", nil, "Set a skill variable for the prompt format (name=value, repeatable)")
+	ExportCmd.Flags().StringVarP(&exportOutput, "file", "o", "", "Output file path (default: stdout)")
 }
@@ -31,6 +31,22 @@ var groupListCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			out := []GroupOutput{}
+			for _, group := range groups {
+				skillsInGroup, err := mgr.ListByGroup(group)
+				if err != nil {
+					return err
+				}
+				entry := GroupOutput{Name: group, Skills: []string{}}
+				for _, skill := range skillsInGroup {
+					entry.Skills = append(entry.Skills, skill.Name)
+				}
+				out = append(out, entry)
+			}
+			return printStructured(out)
+		}
+
 		if len(groups) == 0 {
 			fmt.Println("No groups defined.")
 			fmt.Println("Add a group to a skill with: openskill edit <skill> --group <name>")
@@ -81,7 +97,7 @@ var groupShowCmd = &cobra.Command{
 			if len(skill.Tags) > 0 {
 				fmt.Printf("  Tags: %s", strings.Join(skill.Tags, ", "))
 			}
-			fmt.Println("\n")
+			fmt.Print("\n\n")
 		}
 
 		return nil
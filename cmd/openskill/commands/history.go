@@ -38,7 +38,7 @@ func runHistory(cmd *cobra.Command, args []string) error {
 	skillDir := filepath.Join(".claude/skills", safeName)
 	skillPath := filepath.Join(skillDir, "SKILL.md")
 	if _, err := os.Stat(skillPath); os.IsNotExist(err) {
-		return fmt.Errorf("skill '%s' not found", name)
+		return notFoundError(name)
 	}
 
 	// Get skill file info
@@ -77,6 +77,18 @@ func runHistory(cmd *cobra.Command, args []string) error {
 		return versions[i].Version > versions[j].Version
 	})
 
+	if structuredOutput() {
+		out := HistoryOutput{Skill: name, Current: info.ModTime(), Versions: []VersionOutput{}}
+		for _, v := range versions {
+			out.Versions = append(out.Versions, VersionOutput{
+				Version:   v.Version,
+				Timestamp: v.Timestamp,
+				Path:      filepath.Join(historyPath, v.Filename),
+			})
+		}
+		return printStructured(out)
+	}
+
 	fmt.Println()
 	fmt.Printf("  Version History: %s\n", name)
 	fmt.Println("  ════════════════════════════════════════════")
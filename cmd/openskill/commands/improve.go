@@ -1,18 +1,26 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
 	"openskill/pkg/skills"
+	"openskill/pkg/usage"
 
 	"github.com/spf13/cobra"
 )
 
 var improveApply bool
 
+// skillReview is the structured reply `improve` asks for
+type skillReview struct {
+	Assessment          string   `json:"assessment" desc:"Overall assessment in 1-2 sentences"`
+	Issues              []string `json:"issues" desc:"Specific issues with existing rules; empty if none"`
+	ImprovedRules       []string `json:"improved_rules" desc:"The complete improved rule list, including new rules for missing edge cases"`
+	ImprovedDescription string   `json:"improved_description" desc:"A better description, or an empty string to keep the current one"`
+}
+
 var ImproveCmd = &cobra.Command{
 	Use:   "improve <skill-name>",
 	Short: "Use AI to suggest improvements to a skill",
@@ -42,57 +50,18 @@ This command reviews your skill's rules and description, identifying:
 
 		fmt.Printf("Analyzing skill '%s' with %s...\n\n", name, gen.ProviderName())
 
-		// Build analysis prompt
-		var rulesText strings.Builder
-		for i, rule := range skill.Rules {
-			rulesText.WriteString(fmt.Sprintf("%d. %s\n", i+1, rule))
-		}
-
-		prompt := fmt.Sprintf(`Analyze this skill definition and suggest improvements.
-
-Skill Name: %s
-Description: %s
-
-Current Rules:
-%s
-
-Analyze the skill and provide:
-1. Overall assessment (1-2 sentences)
-2. Specific issues with existing rules (if any)
-3. Suggested new or improved rules
-4. Any missing edge cases or considerations
-
-Return your response as JSON:
-{
-  "assessment": "Overall assessment here",
-  "issues": ["issue 1", "issue 2"],
-  "improved_rules": ["improved rule 1", "improved rule 2", ...],
-  "improved_description": "Better description if needed, or empty string"
-}`, skill.Name, skill.Description, rulesText.String())
-
-		response, err := gen.Provider().Generate(prompt)
+		prompt, err := prompts.Render(prompts.Improve, prompts.SkillData{
+			Name:        skill.Name,
+			Description: skill.Description,
+			Rules:       skill.Rules,
+		})
 		if err != nil {
-			return fmt.Errorf("AI analysis failed: %w", err)
+			return err
 		}
 
-		// Clean and parse response
-		response = strings.TrimSpace(response)
-		response = strings.TrimPrefix(response, "```json")
-		response = strings.TrimPrefix(response, "```")
-		response = strings.TrimSuffix(response, "```")
-		response = strings.TrimSpace(response)
-
-		var result struct {
-			Assessment          string   `json:"assessment"`
-			Issues              []string `json:"issues"`
-			ImprovedRules       []string `json:"improved_rules"`
-			ImprovedDescription string   `json:"improved_description"`
-		}
-
-		if err := json.Unmarshal([]byte(response), &result); err != nil {
-			fmt.Println("AI Response:")
-			fmt.Println(response)
-			return nil
+		var result skillReview
+		if err := llm.GenerateJSON(usage.WithSkill(cmd.Context(), name), gen.Provider(), llm.PromptRequest(prompt), "skill_review", &result); err != nil {
+			return fmt.Errorf("AI analysis failed: %w", err)
 		}
 
 		// Display results
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"openskill/pkg/eval"
+	"openskill/pkg/llm"
+
+	"github.com/spf13/cobra"
+)
+
+var judgeProviderName string
+var judgeModel string
+
+// addJudgeFlags registers the flags that choose the judge model
+func addJudgeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&judgeProviderName, "judge-provider", "", "Provider that judges responses (default: the generating provider)")
+	cmd.Flags().StringVar(&judgeModel, "judge-model", "", "Model that judges responses (default: the judge provider's model)")
+}
+
+// judgeProvider returns the provider chosen by --judge-provider and
+// --judge-model, or gen when neither is set
+func judgeProvider(gen llm.Provider) (llm.Provider, error) {
+	if judgeProviderName == "" && judgeModel == "" {
+		return gen, nil
+	}
+	name := judgeProviderName
+	if name == "" {
+		name = llm.Unwrap(gen).Name()
+	}
+	if _, ok := llm.LookupProvider(name); !ok {
+		return nil, UsageError(fmt.Errorf("unknown judge provider: %s (valid: %s)", name, strings.Join(llm.ProviderNames(), ", ")))
+	}
+	judge := llm.GetProviderWithModel(name, judgeModel)
+	if !judge.IsConfigured() {
+		return nil, fmt.Errorf("judge provider %s is not configured", name)
+	}
+	return judge, nil
+}
+
+// providerLabel names a provider and its model, e.g. "groq (llama-3.3-70b)"
+func providerLabel(p llm.Provider) string {
+	return fmt.Sprintf("%s (%s)", p.Name(), displayModel(llm.ModelOf(p)))
+}
+
+// printScorecard prints rule-by-rule compliance
+func printScorecard(card *eval.Scorecard, judge llm.Provider) {
+	fmt.Printf("\nRule compliance over %d response(s), judged by %s\n", card.Responses, providerLabel(judge))
+	fmt.Println("───────────────────────────────────────────────────")
+	fmt.Printf("  %-3s %-6s %-9s %-9s %-4s  %s\n", "#", "Score", "Followed", "Violated", "N/A", "Rule")
+	for _, r := range card.Rules {
+		glyph := "✓"
+		if r.Violated > 0 {
+			glyph = "❌"
+		} else if r.Followed == 0 {
+			glyph = "·"
+		}
+		fmt.Printf("  %-3d %-6s %-9d %-9d %-4d  %s %s\n", r.Rule, percent(r.Compliance()), r.Followed, r.Violated, r.NotApplicable, glyph, truncate(r.Text, 60))
+		for _, evidence := range r.Violations {
+			fmt.Printf("  %36s → %s\n", "", truncate(evidence, 80))
+		}
+	}
+	fmt.Println("───────────────────────────────────────────────────")
+	fmt.Printf("  Overall compliance: %s\n\n", percent(card.Compliance()))
+}
+
+// percent formats a 0-1 score, or "n/a" for a negative one
+func percent(score float64) string {
+	if score < 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", score*100)
+}
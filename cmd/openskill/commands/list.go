@@ -1,10 +1,17 @@
 package commands
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"text/tabwriter"
+	"text/template"
 
 	"openskill/pkg/core"
+	"openskill/pkg/query"
 	"openskill/pkg/skills"
 
 	"github.com/spf13/cobra"
@@ -13,6 +20,14 @@ import (
 var listTag string
 var listGroup string
 var listVerbose bool
+var listWhere string
+var listSort string
+var listLimit int
+var listColumns string
+var listFormat string
+
+// defaultListColumns are shown by the table and CSV formats when --columns is not set
+const defaultListColumns = "name,version,group,rules,tags"
 
 var ListCmd = &cobra.Command{
 	Use:     "list",
@@ -21,11 +36,26 @@ var ListCmd = &cobra.Command{
 	Long: `List all skills, optionally filtered by tag or group.
 
 Use --tag to filter by tag, --group to filter by group.
-Use --verbose to see more details about each skill.`,
+Use --verbose to see more details about each skill.
+
+Use --where for richer filters. Expressions compare fields with
+=, !=, <, <=, >, >=, has, contains and matches (regex), and combine
+with and, or, not and parentheses. List fields (tags, rules, includes,
+chain) compare by length against numbers.
+
+Fields: name, description, rules, tags, group, author, version,
+template, extends, includes, chain, output_format
+
+Output formats (--format): table, json, csv, or a Go template that is
+executed once per skill, e.g. '{{.Name}}: {{len .Rules}} rules'.`,
 	Example: `  openskill list
   openskill list --tag security
   openskill list --group development
-  openskill list -v`,
+  openskill list -v
+  openskill list --where 'tags has security and rules >= 5 and author = "x"'
+  openskill list --sort -rules,name --limit 10 --format table
+  openskill list --columns name,group,tags --format csv
+  openskill list --format '{{.Name}} ({{len .Rules}} rules)'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mgr := skills.NewManager()
 
@@ -49,6 +79,43 @@ Use --verbose to see more details about each skill.`,
 			}
 		}
 
+		if listWhere != "" {
+			expr, err := query.ParseWith(listWhere, query.Options{TagMatch: mgr.TagMatches})
+			if err != nil {
+				return fmt.Errorf("invalid --where expression: %w", err)
+			}
+			skillList = query.Filter(skillList, expr)
+		}
+
+		if listSort != "" {
+			keys, err := query.ParseSort(listSort)
+			if err != nil {
+				return fmt.Errorf("invalid --sort: %w", err)
+			}
+			query.Sort(skillList, keys)
+		}
+
+		if listLimit > 0 && len(skillList) > listLimit {
+			skillList = skillList[:listLimit]
+		}
+
+		format := listFormat
+		if format == "" && listColumns != "" {
+			format = "table"
+		}
+		if format == "" && structuredOutput() {
+			if OutputFormat == "yaml" {
+				if skillList == nil {
+					skillList = []core.Skill{}
+				}
+				return printStructured(skillList)
+			}
+			format = "json"
+		}
+		if format != "" {
+			return printSkillList(os.Stdout, skillList, format, listColumns)
+		}
+
 		if len(skillList) == 0 {
 			if listTag != "" {
 				fmt.Printf("No skills found with tag '%s'\n", listTag)
@@ -155,8 +222,102 @@ Use --verbose to see more details about each skill.`,
 	},
 }
 
+// printSkillList renders skills in a machine-friendly format
+func printSkillList(w io.Writer, skillList []core.Skill, format, columns string) error {
+	var cols []query.Field
+	if columns != "" || format == "table" || format == "csv" {
+		if columns == "" {
+			columns = defaultListColumns
+		}
+		var err error
+		cols, err = query.ParseColumns(columns)
+		if err != nil {
+			return fmt.Errorf("invalid --columns: %w", err)
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		var header []string
+		for _, c := range cols {
+			header = append(header, strings.ToUpper(c.Name))
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for i := range skillList {
+			var row []string
+			for _, c := range cols {
+				row = append(row, c.Display(&skillList[i]))
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		var header []string
+		for _, c := range cols {
+			header = append(header, c.Name)
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		for i := range skillList {
+			var row []string
+			for _, c := range cols {
+				row = append(row, c.Display(&skillList[i]))
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case "json":
+		var data interface{} = skillList
+		if cols != nil {
+			rows := make([]map[string]interface{}, 0, len(skillList))
+			for i := range skillList {
+				rows = append(rows, query.Project(&skillList[i], cols))
+			}
+			data = rows
+		} else if skillList == nil {
+			data = []core.Skill{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	if !strings.Contains(format, "{{") {
+		return fmt.Errorf("unknown format '%s' (valid: table, json, csv, or a Go template)", format)
+	}
+
+	tmpl, err := template.New("list").Funcs(template.FuncMap{
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}).Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid format template: %w", err)
+	}
+	for i := range skillList {
+		if err := tmpl.Execute(w, skillList[i]); err != nil {
+			return fmt.Errorf("format template failed: %w", err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
 func init() {
 	ListCmd.Flags().StringVarP(&listTag, "tag", "t", "", "Filter by tag")
 	ListCmd.Flags().StringVarP(&listGroup, "group", "g", "", "Filter by group")
 	ListCmd.Flags().BoolVarP(&listVerbose, "verbose", "v", false, "Show detailed information")
+	ListCmd.Flags().StringVarP(&listWhere, "where", "w", "", "Filter expression, e.g. 'tags has security and rules >= 5'")
+	ListCmd.Flags().StringVar(&listSort, "sort", "", "Sort by comma-separated fields; prefix with - for descending")
+	ListCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most this many skills")
+	ListCmd.Flags().StringVar(&listColumns, "columns", "", "Comma-separated fields to show (default: "+defaultListColumns+")")
+	ListCmd.Flags().StringVarP(&listFormat, "format", "f", "", "Output format: table, json, csv, or a Go template")
 }
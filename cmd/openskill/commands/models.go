@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"openskill/pkg/config"
+	"openskill/pkg/llm"
+
+	"github.com/spf13/cobra"
+)
+
+var modelsRefresh bool
+
+var ModelsCmd = &cobra.Command{
+	Use:   "models [provider...]",
+	Short: "List the models each provider offers",
+	Long: `List the models available from each configured provider, marking the one
+currently in use. Lists come from each provider's API (OpenAI and Groq
+/v1/models, the Anthropic models API, Ollama /api/tags) and are cached in
+~/.openskill/models.json for a day.
+
+The cached lists are also used to check and tab-complete
+'openskill config set <provider>-model'.`,
+	Example: `  openskill models
+  openskill models groq --refresh`,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return llm.ProviderNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providers := args
+		if len(providers) == 0 {
+			providers = llm.GetAvailableProviders()
+		}
+		for _, name := range providers {
+			if _, ok := llm.LookupProvider(name); !ok {
+				return UsageError(fmt.Errorf("unknown provider: %s (valid: %s)", name, strings.Join(llm.ProviderNames(), ", ")))
+			}
+		}
+
+		var out []ModelsOutput
+		for _, name := range providers {
+			entry := ModelsOutput{Provider: name, Current: config.GetProviderModel(name)}
+			list, cached, err := llm.Models(cmd.Context(), name, modelsRefresh)
+			if err != nil {
+				if cmd.Context().Err() != nil {
+					return err
+				}
+				entry.Error = err.Error()
+			} else {
+				entry.Models = list.Models
+				entry.Cached = cached
+				fetched := list.FetchedAt
+				entry.FetchedAt = &fetched
+			}
+			out = append(out, entry)
+		}
+
+		if structuredOutput() {
+			return printStructured(out)
+		}
+
+		fmt.Println()
+		for _, entry := range out {
+			fmt.Printf("  %s", entry.Provider)
+			if entry.Cached {
+				fmt.Printf(" (cached %s ago)", time.Since(*entry.FetchedAt).Round(time.Minute))
+			}
+			fmt.Println()
+			fmt.Println("  ───────────────────────────────────")
+			if entry.Error != "" {
+				fmt.Printf("  ❌ %s\n\n", entry.Error)
+				continue
+			}
+			if len(entry.Models) == 0 {
+				fmt.Printf("  (no models)\n")
+			}
+			for _, model := range entry.Models {
+				if containsModel([]string{model}, entry.Current) {
+					fmt.Printf("  ● %s (current)\n", model)
+				} else {
+					fmt.Printf("    %s\n", model)
+				}
+			}
+			if entry.Current != "" && !containsModel(entry.Models, entry.Current) {
+				fmt.Printf("  ⚠ current model %s is not in this list\n", entry.Current)
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+func containsModel(models []string, model string) bool {
+	return llm.ModelList{Models: models}.Contains(model)
+}
+
+// modelKeyProvider maps a `config set` model key to its provider
+func modelKeyProvider(key string) (string, bool) {
+	if key == "model" {
+		return config.GetProvider(), true
+	}
+	provider := strings.TrimSuffix(key, "-model")
+	if provider == key {
+		return "", false
+	}
+	if _, ok := config.DefaultModels[provider]; !ok {
+		return "", false
+	}
+	return provider, true
+}
+
+// modelsForCompletion returns a provider's models for shell completion,
+// fetching them briefly when nothing is cached
+func modelsForCompletion(provider string) []string {
+	if list, ok := llm.CachedModels(provider); ok {
+		return list.Models
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	list, err := llm.FetchModels(ctx, provider)
+	if err != nil {
+		return nil
+	}
+	return list.Models
+}
+
+// checkModel rejects a model the provider doesn't list. Providers whose
+// list can't be fetched are not checked.
+func checkModel(ctx context.Context, provider, model string) error {
+	list, _, err := llm.Models(ctx, provider, false)
+	if err != nil || len(list.Models) == 0 || list.Contains(model) {
+		return nil
+	}
+
+	var similar []string
+	for _, m := range list.Models {
+		if strings.Contains(m, model) || strings.Contains(model, m) {
+			similar = append(similar, m)
+		}
+	}
+	msg := fmt.Sprintf("%s does not offer model '%s'", provider, model)
+	if len(similar) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(similar, ", "))
+	}
+	return UsageError(fmt.Errorf("%s; run 'openskill models %s' to see them, or pass --force", msg, provider))
+}
+
+func init() {
+	ModelsCmd.Flags().BoolVar(&modelsRefresh, "refresh", false, "Fetch model lists again instead of using the cache")
+}
@@ -0,0 +1,450 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"openskill/pkg/core"
+	"openskill/pkg/eval"
+	"openskill/pkg/lint"
+	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
+	"openskill/pkg/usage"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat is set by the global --output flag (text, json or yaml)
+var OutputFormat = "text"
+
+// CheckOutputFormat validates the global --output flag
+func CheckOutputFormat() error {
+	OutputFormat = strings.ToLower(OutputFormat)
+	switch OutputFormat {
+	case "text", "json", "yaml":
+		return nil
+	}
+	return UsageError(fmt.Errorf("invalid --output '%s' (valid: text, json, yaml)", OutputFormat))
+}
+
+// structuredOutput reports whether commands should emit JSON/YAML documents
+func structuredOutput() bool {
+	return OutputFormat == "json" || OutputFormat == "yaml"
+}
+
+// printStructured writes v to stdout in the selected structured format
+func printStructured(v interface{}) error {
+	return writeStructured(os.Stdout, v)
+}
+
+func writeStructured(w io.Writer, v interface{}) error {
+	if OutputFormat == "yaml" {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// progressf prints progress messages that are not part of the command's
+// result. In structured mode they go to stderr so stdout stays parseable.
+func progressf(format string, args ...interface{}) {
+	if structuredOutput() {
+		fmt.Fprintf(os.Stderr, format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// streamPrinter returns a callback that prints streamed tokens to stdout
+// as they arrive, dropping leading whitespace from the reply
+func streamPrinter() llm.StreamFunc {
+	started := false
+	return func(chunk string) error {
+		if !started {
+			chunk = strings.TrimLeft(chunk, " \t\r\n")
+			if chunk == "" {
+				return nil
+			}
+			started = true
+		}
+		_, err := fmt.Print(chunk)
+		return err
+	}
+}
+
+// fallbackNotice tells the user a provider in the fallback chain failed
+// and which one is tried next
+func fallbackNotice(from, to string, err error) {
+	fmt.Fprintf(os.Stderr, "⚠ %s failed: %v\n  Falling back to %s...\n", from, err, to)
+}
+
+func init() {
+	llm.OnFallback = fallbackNotice
+}
+
+// ============== Errors ==============
+
+// Error codes reported in structured output
+const (
+	CodeError            = "error"
+	CodeUsage            = "usage_error"
+	CodeNotFound         = "not_found"
+	CodeValidationFailed = "validation_failed"
+	CodeConfig           = "config_error"
+	CodeProvider         = "provider_error"
+	CodeGit              = "git_error"
+	CodeInterrupted      = "interrupted"
+	CodeBudget           = "budget_exceeded"
+	CodeChecksFailed     = "checks_failed"
+	CodeTestsFailed      = "tests_failed"
+)
+
+// CommandError carries a stable machine-readable code alongside an error
+type CommandError struct {
+	Code string
+	Err  error
+
+	// Reported is set when the command's result document already
+	// describes the failure; PrintError then leaves stdout alone so it
+	// holds a single document, and the error only sets the exit status
+	Reported bool
+}
+
+func (e *CommandError) Error() string { return e.Err.Error() }
+func (e *CommandError) Unwrap() error { return e.Err }
+
+func newCodedError(code string, err error) error {
+	return &CommandError{Code: code, Err: err}
+}
+
+// reportedError marks err as already described by the structured result
+// printed on stdout. Errors without a code get CodeError.
+func reportedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var ce *CommandError
+	if !errors.As(err, &ce) {
+		ce = &CommandError{Code: CodeError, Err: err}
+	}
+	return &CommandError{Code: ce.Code, Err: ce.Err, Reported: true}
+}
+
+// UsageError marks err as a command-line usage mistake
+func UsageError(err error) error {
+	return newCodedError(CodeUsage, err)
+}
+
+// InterruptedError marks err as caused by Ctrl-C
+func InterruptedError(err error) error {
+	return newCodedError(CodeInterrupted, err)
+}
+
+// notFoundError reports a missing skill
+func notFoundError(name string) error {
+	return newCodedError(CodeNotFound, fmt.Errorf("skill '%s' not found", name))
+}
+
+// ErrorCode returns the code attached to err, or CodeError
+func ErrorCode(err error) string {
+	var ce *CommandError
+	if errors.As(err, &ce) {
+		return ce.Code
+	}
+	var budgetErr *usage.BudgetError
+	if errors.As(err, &budgetErr) {
+		return CodeBudget
+	}
+	var apiErr *llm.APIError
+	var structErr *llm.StructuredError
+	var fixtureErr *llm.FixtureMissError
+	if errors.As(err, &apiErr) || errors.As(err, &structErr) || errors.As(err, &fixtureErr) || errors.Is(err, llm.ErrCacheMiss) {
+		return CodeProvider
+	}
+	return CodeError
+}
+
+// ExitCode maps an error to the process exit status
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	switch ErrorCode(err) {
+	case CodeUsage:
+		return 2
+	case CodeInterrupted:
+		return 130 // Conventional status for SIGINT
+	}
+	return 1
+}
+
+// ErrorOutput is the document printed instead of a command's normal
+// output when it fails in structured mode
+type ErrorOutput struct {
+	Error ErrorDetail `json:"error" yaml:"error"`
+}
+
+// ErrorDetail describes a failure
+type ErrorDetail struct {
+	Code    string `json:"code" yaml:"code"`
+	Message string `json:"message" yaml:"message"`
+	Kind    string `json:"kind,omitempty" yaml:"kind,omitempty"` // Provider error kind, e.g. rate_limit
+	Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"` // Suggested fix
+}
+
+// PrintError reports a command failure in the selected output format
+func PrintError(err error) {
+	detail := ErrorDetail{Code: ErrorCode(err), Message: err.Error()}
+	var apiErr *llm.APIError
+	if errors.As(err, &apiErr) {
+		detail.Kind = string(apiErr.Kind)
+		detail.Hint = apiErr.Hint()
+	}
+
+	if structuredOutput() {
+		var ce *CommandError
+		if errors.As(err, &ce) && ce.Reported {
+			return
+		}
+		if writeStructured(os.Stdout, ErrorOutput{Error: detail}) == nil {
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if detail.Hint != "" {
+		fmt.Fprintf(os.Stderr, "  Hint: %s\n", detail.Hint)
+	}
+}
+
+// ============== Output documents ==============
+
+// SkillOutput is emitted by `show`
+type SkillOutput struct {
+	core.Skill `yaml:",inline"`
+	Path       string `json:"path" yaml:"path"`
+}
+
+// HistoryOutput is emitted by `history`
+type HistoryOutput struct {
+	Skill    string          `json:"skill" yaml:"skill"`
+	Current  time.Time       `json:"current" yaml:"current"` // Modification time of the active SKILL.md
+	Versions []VersionOutput `json:"versions" yaml:"versions"`
+}
+
+// VersionOutput describes one saved version
+type VersionOutput struct {
+	Version   int       `json:"version" yaml:"version"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Path      string    `json:"path" yaml:"path"`
+}
+
+// DiffOutput is emitted by `diff`
+type DiffOutput struct {
+	Skill     string     `json:"skill" yaml:"skill"`
+	From      string     `json:"from" yaml:"from"` // "current" or "vN"
+	To        string     `json:"to" yaml:"to"`
+	Identical bool       `json:"identical" yaml:"identical"`
+	Changes   []DiffLine `json:"changes" yaml:"changes"`
+}
+
+// DiffLine is a removed ("-") or added ("+") line
+type DiffLine struct {
+	Op   string `json:"op" yaml:"op"`
+	Line int    `json:"line" yaml:"line"` // 1-based line number in the compared files
+	Text string `json:"text" yaml:"text"`
+}
+
+// ValidateOutput is emitted by `validate`
+type ValidateOutput struct {
+	Skill    string   `json:"skill" yaml:"skill"`
+	Valid    bool     `json:"valid" yaml:"valid"`
+	Errors   []string `json:"errors" yaml:"errors"`
+	Warnings []string `json:"warnings" yaml:"warnings"`
+
+	// Findings carries every lint finding with its rule, severity and position
+	Findings []lint.Finding `json:"findings" yaml:"findings"`
+}
+
+// ValidateReportOutput is emitted by `validate --all` and `--changed-since`
+type ValidateReportOutput struct {
+	Strict  bool             `json:"strict" yaml:"strict"`
+	Checked int              `json:"checked" yaml:"checked"`
+	Failed  int              `json:"failed" yaml:"failed"`
+	Skills  []ValidateOutput `json:"skills" yaml:"skills"`
+}
+
+// LintRuleOutput is one entry of `validate --list-rules`
+type LintRuleOutput struct {
+	ID              string `json:"id" yaml:"id"`
+	Summary         string `json:"summary" yaml:"summary"`
+	Docs            string `json:"docs" yaml:"docs"`
+	DefaultSeverity string `json:"default_severity" yaml:"default_severity"`
+	Severity        string `json:"severity" yaml:"severity"` // Effective severity, empty when disabled
+	Enabled         bool   `json:"enabled" yaml:"enabled"`
+}
+
+// TemplateOutput is one entry of `template list`
+type TemplateOutput struct {
+	Name        string   `json:"name" yaml:"name"`
+	Category    string   `json:"category" yaml:"category"`
+	Description string   `json:"description" yaml:"description"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	Rules       int      `json:"rules" yaml:"rules"`
+}
+
+// WorkspaceOutput is emitted by `workspace show`
+type WorkspaceOutput struct {
+	Configured bool            `json:"configured" yaml:"configured"`
+	Workspace  *core.Workspace `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+}
+
+// GroupOutput is one entry of `group list`
+type GroupOutput struct {
+	Name   string   `json:"name" yaml:"name"`
+	Skills []string `json:"skills" yaml:"skills"`
+}
+
+// ConfigOutput is emitted by `config list`. API keys are masked.
+type ConfigOutput struct {
+	Provider        string            `json:"provider" yaml:"provider"`
+	Providers       []string          `json:"providers,omitempty" yaml:"providers,omitempty"` // Fallback chain
+	APIKeys         map[string]string `json:"api_keys" yaml:"api_keys"`
+	Models          map[string]string `json:"models" yaml:"models"`
+	OllamaEndpoint  string            `json:"ollama_endpoint" yaml:"ollama_endpoint"`
+	CustomProviders map[string]string `json:"custom_providers,omitempty" yaml:"custom_providers,omitempty"` // Name to base URL
+	Plugins         map[string]string `json:"plugins,omitempty" yaml:"plugins,omitempty"`                   // Name to executable
+	MaxRetries      int               `json:"max_retries" yaml:"max_retries"`
+	RetryMaxWait    string            `json:"retry_max_wait" yaml:"retry_max_wait"`
+	Configured      []string          `json:"configured" yaml:"configured"`
+	ConfigFile      string            `json:"config_file" yaml:"config_file"`
+}
+
+// CacheStatsOutput is emitted by `cache stats`
+type CacheStatsOutput struct {
+	Dir      string     `json:"dir" yaml:"dir"`
+	Enabled  bool       `json:"enabled" yaml:"enabled"`
+	TTL      string     `json:"ttl" yaml:"ttl"`
+	MaxBytes int64      `json:"max_bytes" yaml:"max_bytes"`
+	Entries  int        `json:"entries" yaml:"entries"`
+	Expired  int        `json:"expired" yaml:"expired"`
+	Bytes    int64      `json:"bytes" yaml:"bytes"`
+	Oldest   *time.Time `json:"oldest,omitempty" yaml:"oldest,omitempty"`
+	Newest   *time.Time `json:"newest,omitempty" yaml:"newest,omitempty"`
+}
+
+// CacheClearOutput is emitted by `cache clear`
+type CacheClearOutput struct {
+	Removed     int  `json:"removed" yaml:"removed"`
+	ExpiredOnly bool `json:"expired_only" yaml:"expired_only"`
+}
+
+// UsageOutput is emitted by `usage`
+type UsageOutput struct {
+	Since  time.Time     `json:"since" yaml:"since"`
+	By     string        `json:"by" yaml:"by"`
+	Groups []usage.Total `json:"groups" yaml:"groups"`
+	Total  usage.Total   `json:"total" yaml:"total"`
+}
+
+// PromptOutput is emitted by `prompts show`
+type PromptOutput struct {
+	prompts.Prompt `yaml:",inline"`
+	Text           string `json:"text" yaml:"text"`
+}
+
+// ModelsOutput is one provider's entry in `models`
+type ModelsOutput struct {
+	Provider  string     `json:"provider" yaml:"provider"`
+	Current   string     `json:"current" yaml:"current"` // Configured model
+	Models    []string   `json:"models" yaml:"models"`
+	Cached    bool       `json:"cached" yaml:"cached"`
+	FetchedAt *time.Time `json:"fetched_at,omitempty" yaml:"fetched_at,omitempty"`
+	Error     string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// RunOutput is emitted by `run`
+type RunOutput struct {
+	Skill            string `json:"skill" yaml:"skill"`
+	Provider         string `json:"provider" yaml:"provider"`
+	Model            string `json:"model" yaml:"model"`
+	Response         string `json:"response" yaml:"response"`
+	PromptTokens     int    `json:"prompt_tokens" yaml:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens" yaml:"completion_tokens"`
+	Cached           bool   `json:"cached" yaml:"cached"`
+}
+
+// SuiteOutput is emitted by `test --suite`
+type SuiteOutput struct {
+	Skill    string            `json:"skill" yaml:"skill"`
+	Provider string            `json:"provider" yaml:"provider"`
+	Passed   int               `json:"passed" yaml:"passed"`
+	Failed   int               `json:"failed" yaml:"failed"`
+	Cases    []eval.CaseResult `json:"cases" yaml:"cases"`
+
+	Scorecard *eval.Scorecard `json:"scorecard,omitempty" yaml:"scorecard,omitempty"` // With --judge
+}
+
+// JudgeOutput is emitted by `test --prompt --judge`
+type JudgeOutput struct {
+	Skill     string          `json:"skill" yaml:"skill"`
+	Provider  string          `json:"provider" yaml:"provider"`
+	Judge     string          `json:"judge" yaml:"judge"`
+	Scorecard *eval.Scorecard `json:"scorecard" yaml:"scorecard"`
+}
+
+// CompareOutput is emitted by `eval compare`
+type CompareOutput struct {
+	Skill    string            `json:"skill" yaml:"skill"`
+	V1       string            `json:"v1" yaml:"v1"`
+	V2       string            `json:"v2" yaml:"v2"`
+	Provider string            `json:"provider" yaml:"provider"`
+	Judge    string            `json:"judge" yaml:"judge"`
+	Seed     int64             `json:"seed" yaml:"seed"`
+	Results  []eval.PairResult `json:"results" yaml:"results"`
+	Summary  eval.WinRate      `json:"summary" yaml:"summary"`
+}
+
+// BenchOutput is emitted by `bench` and written to its reports
+type BenchOutput struct {
+	Skill       string             `json:"skill" yaml:"skill"`
+	Judge       string             `json:"judge" yaml:"judge"`
+	Cases       int                `json:"cases" yaml:"cases"`
+	MinPassRate float64            `json:"min_pass_rate" yaml:"min_pass_rate"`
+	Recommended string             `json:"recommended,omitempty" yaml:"recommended,omitempty"` // Cheapest target reaching MinPassRate
+	Date        string             `json:"date" yaml:"date"`
+	Targets     []eval.BenchResult `json:"targets" yaml:"targets"`
+}
+
+// DoctorCheck is one diagnostic reported by `doctor`
+type DoctorCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"` // pass, warn or fail
+	Detail string `json:"detail" yaml:"detail"`
+	Hint   string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// DoctorOutput is emitted by `doctor`
+type DoctorOutput struct {
+	Checks   []DoctorCheck `json:"checks" yaml:"checks"`
+	Warnings int           `json:"warnings" yaml:"warnings"`
+	Failed   int           `json:"failed" yaml:"failed"`
+}
+
+// SyncOutput is emitted by `sync`
+type SyncOutput struct {
+	Action  string   `json:"action" yaml:"action"` // status, remote, push or pull
+	Remote  string   `json:"remote" yaml:"remote"`
+	Synced  bool     `json:"synced" yaml:"synced"`
+	Changes []string `json:"changes" yaml:"changes"` // git status --short lines
+}
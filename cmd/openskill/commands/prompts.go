@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+
+	"openskill/pkg/prompts"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	promptsShowBuiltin bool
+	promptsEjectUser   bool
+	promptsEjectForce  bool
+)
+
+var PromptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Inspect and customise AI prompt templates",
+	Long: `The prompts behind add, improve and explain are Go text/template files.
+A copy in .claude/prompts (per project) or ~/.openskill/prompts (per user)
+replaces the built-in one, so a team can add house rules such as "always
+include an escalation rule" to skill generation.
+
+Each template starts with a comment listing the variables it receives.`,
+}
+
+var promptsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List prompts and where each is loaded from",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := prompts.List()
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return printStructured(list)
+		}
+
+		fmt.Println("\nPrompts:")
+		fmt.Println("────────")
+		for _, p := range list {
+			fmt.Printf("  %-10s %-9s %s\n", p.Name, p.Source, p.Description)
+			if p.Path != "" {
+				fmt.Printf("  %-10s %-9s %s\n", "", "", p.Path)
+			}
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print the template a prompt resolves to",
+	Args:  cobra.ExactArgs(1),
+	Example: `  openskill prompts show generate
+  openskill prompts show generate --builtin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		if promptsShowBuiltin {
+			text, err := prompts.Builtin(name)
+			if err != nil {
+				return UsageError(err)
+			}
+			fmt.Print(text)
+			return nil
+		}
+
+		p, err := prompts.Load(name)
+		if err != nil {
+			return UsageError(err)
+		}
+		if structuredOutput() {
+			return printStructured(PromptOutput{Prompt: *p, Text: p.Text})
+		}
+		fmt.Print(p.Text)
+		return nil
+	},
+}
+
+var promptsEjectCmd = &cobra.Command{
+	Use:   "eject [name...]",
+	Short: "Copy built-in prompts to .claude/prompts for editing",
+	Long: `Copy built-in prompt templates into .claude/prompts (or ~/.openskill/prompts
+with --user) so they can be edited. Ejects every prompt when no name is given.`,
+	Example: `  openskill prompts eject generate
+  openskill prompts eject --user`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := prompts.ProjectDir
+		if promptsEjectUser {
+			userDir, err := prompts.UserDir()
+			if err != nil {
+				return err
+			}
+			dir = userDir
+		}
+
+		names := args
+		if len(names) == 0 {
+			for _, d := range prompts.Descriptions {
+				names = append(names, d.Name)
+			}
+		}
+		for _, name := range names {
+			if _, err := prompts.Builtin(name); err != nil {
+				return UsageError(err)
+			}
+		}
+
+		for _, name := range names {
+			path, err := prompts.Eject(name, dir, promptsEjectForce)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Wrote %s\n", path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	promptsShowCmd.Flags().BoolVar(&promptsShowBuiltin, "builtin", false, "Show the built-in template, ignoring overrides")
+	promptsEjectCmd.Flags().BoolVar(&promptsEjectUser, "user", false, "Write to ~/.openskill/prompts instead of .claude/prompts")
+	promptsEjectCmd.Flags().BoolVarP(&promptsEjectForce, "force", "f", false, "Overwrite existing files")
+
+	PromptsCmd.AddCommand(promptsListCmd)
+	PromptsCmd.AddCommand(promptsShowCmd)
+	PromptsCmd.AddCommand(promptsEjectCmd)
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"openskill/pkg/core"
+	"openskill/pkg/llm"
+	"openskill/pkg/render"
+	"openskill/pkg/skills"
+	"openskill/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+var runVars []string
+var runExec bool
+
+var RunCmd = &cobra.Command{
+	Use:   "run <skill-name> <input|->",
+	Short: "Run a skill on an input",
+	Long: `Run a skill on an input and print the response.
+
+The skill, composed with its extends and includes, is compiled into a
+request: its description, rules and gathered context become the system
+prompt, its examples become sample turns, and the input the final user
+message. {{name}} placeholders are filled from the skill's variables and
+--var. Pass - to read the input from stdin.
+
+Context files, globs and environment variables are always gathered;
+context commands and URLs only with --exec.`,
+	Args: cobra.MinimumNArgs(2),
+	Example: `  openskill run code-review "func add(a, b int) int { return a - b }"
+  git diff | openskill run commit-message -
+  openskill run translate --var language=French "Good morning"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		input := strings.Join(args[1:], " ")
+		if input == "-" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read stdin: %w", err)
+			}
+			input = string(data)
+		}
+		if strings.TrimSpace(input) == "" {
+			return UsageError(fmt.Errorf("input is empty"))
+		}
+
+		skill, req, bundle, err := compileSkill(cmd.Context(), name, runVars, runExec, input)
+		if err != nil {
+			return err
+		}
+		for _, skipped := range bundle.Skipped {
+			fmt.Fprintf(os.Stderr, "⚠ Context skipped: %s\n", skipped)
+		}
+
+		provider := llm.GetProvider()
+		if !provider.IsConfigured() {
+			return fmt.Errorf("no AI provider configured. Use 'openskill config set api-key'")
+		}
+		ctx := usage.WithSkill(cmd.Context(), skill.Name)
+
+		if structuredOutput() {
+			resp, err := provider.Chat(ctx, req)
+			if err != nil {
+				return err
+			}
+			served := resp.Provider
+			if served == "" {
+				served = provider.Name()
+			}
+			return printStructured(RunOutput{
+				Skill:            skill.Name,
+				Provider:         served,
+				Model:            resp.Model,
+				Response:         resp.Content,
+				PromptTokens:     resp.Usage.PromptTokens,
+				CompletionTokens: resp.Usage.CompletionTokens,
+				Cached:           resp.Cached,
+			})
+		}
+
+		_, err = llm.ChatStream(ctx, provider, req, streamPrinter())
+		fmt.Println()
+		return err
+	},
+}
+
+// compileSkill resolves a skill, gathers its context and compiles it with
+// the given variable assignments and input
+func compileSkill(ctx context.Context, name string, assignments []string, exec bool, input string) (*core.Skill, llm.Request, *render.Bundle, error) {
+	vars, err := render.ParseVars(assignments)
+	if err != nil {
+		return nil, llm.Request{}, nil, UsageError(err)
+	}
+	mgr := skills.NewManager()
+	if !mgr.Exists(name) {
+		return nil, llm.Request{}, nil, notFoundError(name)
+	}
+	skill, err := mgr.Resolve(name)
+	if err != nil {
+		return nil, llm.Request{}, nil, err
+	}
+
+	bundle := render.Gather(ctx, skill.Context, render.GatherOptions{Exec: exec})
+	req, err := render.Compile(render.Input{Skill: skill, Context: bundle, Vars: vars, Prompt: input})
+	if err != nil {
+		var missing *render.MissingVarsError
+		if errors.As(err, &missing) {
+			err = UsageError(err)
+		}
+		return nil, llm.Request{}, nil, err
+	}
+	return skill, req, bundle, nil
+}
+
+func init() {
+	RunCmd.Flags().StringArrayVar(&runVars, "var", nil, "Set a skill variable (name=value, repeatable)")
+	RunCmd.Flags().BoolVar(&runExec, "exec", false, "Run the skill's context commands and fetch its context URLs")
+}
@@ -99,7 +99,7 @@ After sharing, you'll receive:
 		if err != nil {
 			// API not available - provide local export instead
 			fmt.Println("  Note: Marketplace API not yet available.")
-			fmt.Println("  Generating shareable export instead...\n")
+			fmt.Print("  Generating shareable export instead...\n\n")
 
 			return generateLocalShare(skill, mgr)
 		}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"openskill/pkg/skills"
 
@@ -18,7 +19,14 @@ var ShowCmd = &cobra.Command{
 		mgr := skills.NewManager()
 		skill, err := mgr.Get(name)
 		if err != nil {
-			return fmt.Errorf("skill '%s' not found", name)
+			return notFoundError(name)
+		}
+
+		if structuredOutput() {
+			return printStructured(SkillOutput{
+				Skill: *skill,
+				Path:  filepath.Join(mgr.GetSkillDir(name), "SKILL.md"),
+			})
 		}
 
 		fmt.Printf("Name: %s\n", skill.Name)
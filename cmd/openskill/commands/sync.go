@@ -41,9 +41,9 @@ This command helps you:
 		if syncRemote != "" {
 			// Initialize or update remote
 			if !isGitRepo {
-				fmt.Println("Initializing git repository in .claude/skills...")
+				progressf("Initializing git repository in .claude/skills...\n")
 				if err := runGitCommand(skillsDir, "init"); err != nil {
-					return fmt.Errorf("git init failed: %w", err)
+					return newCodedError(CodeGit, fmt.Errorf("git init failed: %w", err))
 				}
 			}
 
@@ -52,21 +52,24 @@ This command helps you:
 				// Ignore error if remote doesn't exist
 			}
 			if err := runGitCommand(skillsDir, "remote", "add", "origin", syncRemote); err != nil {
-				return fmt.Errorf("failed to add remote: %w", err)
+				return newCodedError(CodeGit, fmt.Errorf("failed to add remote: %w", err))
 			}
 
+			if structuredOutput() {
+				return printSyncStatus(skillsDir, "remote")
+			}
 			fmt.Printf("✓ Remote set to: %s\n", syncRemote)
 			return nil
 		}
 
 		if !isGitRepo {
-			return fmt.Errorf("skills directory is not a git repository. Use --remote to initialize")
+			return newCodedError(CodeGit, fmt.Errorf("skills directory is not a git repository. Use --remote to initialize"))
 		}
 
 		if syncPush {
 			// Add all changes
 			if err := runGitCommand(skillsDir, "add", "-A"); err != nil {
-				return fmt.Errorf("git add failed: %w", err)
+				return newCodedError(CodeGit, fmt.Errorf("git add failed: %w", err))
 			}
 
 			// Check if there are changes to commit
@@ -74,36 +77,46 @@ This command helps you:
 			if strings.TrimSpace(status) != "" {
 				// Commit changes
 				if err := runGitCommand(skillsDir, "commit", "-m", "Update skills"); err != nil {
-					return fmt.Errorf("git commit failed: %w", err)
+					return newCodedError(CodeGit, fmt.Errorf("git commit failed: %w", err))
 				}
 			}
 
 			// Push to remote
-			fmt.Println("Pushing skills to remote...")
+			progressf("Pushing skills to remote...\n")
 			if err := runGitCommand(skillsDir, "push", "-u", "origin", "main"); err != nil {
 				// Try master branch
 				if err := runGitCommand(skillsDir, "push", "-u", "origin", "master"); err != nil {
-					return fmt.Errorf("git push failed: %w", err)
+					return newCodedError(CodeGit, fmt.Errorf("git push failed: %w", err))
 				}
 			}
 
+			if structuredOutput() {
+				return printSyncStatus(skillsDir, "push")
+			}
 			fmt.Println("✓ Skills pushed to remote")
 			return nil
 		}
 
 		if syncPull {
-			fmt.Println("Pulling skills from remote...")
+			progressf("Pulling skills from remote...\n")
 			if err := runGitCommand(skillsDir, "pull", "origin", "main"); err != nil {
 				// Try master branch
 				if err := runGitCommand(skillsDir, "pull", "origin", "master"); err != nil {
-					return fmt.Errorf("git pull failed: %w", err)
+					return newCodedError(CodeGit, fmt.Errorf("git pull failed: %w", err))
 				}
 			}
 
+			if structuredOutput() {
+				return printSyncStatus(skillsDir, "pull")
+			}
 			fmt.Println("✓ Skills pulled from remote")
 			return nil
 		}
 
+		if structuredOutput() {
+			return printSyncStatus(skillsDir, "status")
+		}
+
 		// Default: show status
 		fmt.Println("Sync Status:")
 		fmt.Println("───────────────────────────────────")
@@ -131,10 +144,32 @@ This command helps you:
 	},
 }
 
+// printSyncStatus emits the structured sync document after an action
+func printSyncStatus(dir, action string) error {
+	out := SyncOutput{Action: action, Changes: []string{}}
+
+	remote, _ := getGitOutput(dir, "remote", "get-url", "origin")
+	out.Remote = strings.TrimSpace(remote)
+
+	status, _ := getGitOutput(dir, "status", "--short")
+	for _, line := range strings.Split(status, "\n") {
+		if strings.TrimSpace(line) != "" {
+			out.Changes = append(out.Changes, line)
+		}
+	}
+	out.Synced = len(out.Changes) == 0
+
+	return printStructured(out)
+}
+
 func runGitCommand(dir string, args ...string) error {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
+	// Keep stdout clean for JSON/YAML documents
 	cmd.Stdout = os.Stdout
+	if structuredOutput() {
+		cmd.Stdout = os.Stderr
+	}
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
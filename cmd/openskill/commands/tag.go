@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"openskill/pkg/core"
 	"openskill/pkg/skills"
 
 	"github.com/spf13/cobra"
@@ -17,7 +18,14 @@ var TagCmd = &cobra.Command{
 Tags allow you to:
 - Categorize skills by multiple dimensions
 - Filter skills by tag
-- Discover related skills`,
+- Discover related skills
+
+Tags are case-insensitive and may be hierarchical, like "lang/go".
+Filtering by a parent tag ("lang") also matches its children.
+
+Descriptions and aliases live in .claude/tags.yaml. An alias such as
+"sec" resolves to its canonical tag ("security") everywhere tags are
+matched, and is rewritten to the canonical tag when added to a skill.`,
 }
 
 var tagListCmd = &cobra.Command{
@@ -26,6 +34,10 @@ var tagListCmd = &cobra.Command{
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mgr := skills.NewManager()
+		counts, err := mgr.TagCounts()
+		if err != nil {
+			return err
+		}
 		tags, err := mgr.GetAllTags()
 		if err != nil {
 			return err
@@ -37,12 +49,28 @@ var tagListCmd = &cobra.Command{
 			return nil
 		}
 
+		registry, err := skills.LoadTagRegistry()
+		if err != nil {
+			return err
+		}
+
 		fmt.Println("\nAll Tags:")
 		fmt.Println("─────────────────────────────────────")
 
 		for _, tag := range tags {
-			skillsWithTag, _ := mgr.ListByTag(tag)
-			fmt.Printf("  %-20s (%d skills)\n", tag, len(skillsWithTag))
+			// Indent children under their parents
+			depth := strings.Count(tag, skills.TagSeparator)
+			label := strings.Repeat("  ", depth) + tag
+			fmt.Printf("  %-20s (%d skills)", label, counts[tag])
+			if def := registry.Tags[tag]; def != nil {
+				if def.Description != "" {
+					fmt.Printf("  %s", def.Description)
+				}
+				if len(def.Aliases) > 0 {
+					fmt.Printf("  [aliases: %s]", strings.Join(def.Aliases, ", "))
+				}
+			}
+			fmt.Println()
 		}
 		fmt.Println()
 
@@ -67,16 +95,23 @@ var tagShowCmd = &cobra.Command{
 			return fmt.Errorf("no skills found with tag '%s'", tagName)
 		}
 
-		fmt.Printf("\nSkills tagged '%s':\n", tagName)
+		canonical := mgr.CanonicalTag(tagName)
+		fmt.Printf("\nSkills tagged '%s':\n", canonical)
 		fmt.Println("═══════════════════════════════════════════════════")
 
+		if registry, err := skills.LoadTagRegistry(); err == nil {
+			if def := registry.Tags[canonical]; def != nil && def.Description != "" {
+				fmt.Printf("%s\n", def.Description)
+			}
+		}
+
 		for _, skill := range skillsWithTag {
 			fmt.Printf("\n  %s\n", skill.Name)
 			fmt.Printf("    %s\n", truncateText(skill.Description, 60))
 			if len(skill.Tags) > 1 {
 				var otherTags []string
 				for _, t := range skill.Tags {
-					if !strings.EqualFold(t, tagName) {
+					if !mgr.TagMatches(t, tagName) {
 						otherTags = append(otherTags, t)
 					}
 				}
@@ -107,18 +142,19 @@ var tagAddCmd = &cobra.Command{
 			return fmt.Errorf("skill '%s' not found", skillName)
 		}
 
-		// Add new tags (avoiding duplicates)
+		// Add new tags (avoiding duplicates), resolving aliases
 		existingTags := make(map[string]bool)
 		for _, t := range skill.Tags {
-			existingTags[strings.ToLower(t)] = true
+			existingTags[mgr.CanonicalTag(t)] = true
 		}
 
 		addedTags := []string{}
 		for _, tag := range newTags {
-			if !existingTags[strings.ToLower(tag)] {
+			tag = mgr.CanonicalTag(tag)
+			if tag != "" && !existingTags[tag] {
 				skill.Tags = append(skill.Tags, tag)
 				addedTags = append(addedTags, tag)
-				existingTags[strings.ToLower(tag)] = true
+				existingTags[tag] = true
 			}
 		}
 
@@ -154,13 +190,13 @@ var tagRemoveCmd = &cobra.Command{
 		// Remove specified tags
 		removeSet := make(map[string]bool)
 		for _, t := range tagsToRemove {
-			removeSet[strings.ToLower(t)] = true
+			removeSet[mgr.CanonicalTag(t)] = true
 		}
 
 		var newTags []string
 		removedTags := []string{}
 		for _, t := range skill.Tags {
-			if removeSet[strings.ToLower(t)] {
+			if removeSet[mgr.CanonicalTag(t)] {
 				removedTags = append(removedTags, t)
 			} else {
 				newTags = append(newTags, t)
@@ -182,9 +218,269 @@ var tagRemoveCmd = &cobra.Command{
 	},
 }
 
+var tagKeepAlias bool
+var tagMergeInto string
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename <old-tag> <new-tag>",
+	Short: "Rename a tag on every skill",
+	Long: `Rename a tag on every skill that uses it. Child tags are renamed too,
+so renaming "golang" to "lang/go" turns "golang/web" into "lang/go/web".
+
+Each changed skill is saved to version history first.`,
+	Args: cobra.ExactArgs(2),
+	Example: `  openskill tag rename sec security
+  openskill tag rename golang lang/go --alias`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr := skills.NewManager()
+		from, to := mgr.CanonicalTag(args[0]), mgr.CanonicalTag(args[1])
+
+		results, err := mgr.RenameTag(from, to)
+		if err != nil {
+			return err
+		}
+		if err := moveTagDefinitions([]string{from}, to, tagKeepAlias); err != nil {
+			return err
+		}
+
+		printRetagResults(results)
+		fmt.Printf("✓ Renamed tag '%s' to '%s'\n", from, to)
+		return nil
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:   "merge <tag>... --into <target>",
+	Short: "Merge several tags into one",
+	Long: `Replace each source tag with the target tag on every skill. Skills that
+end up with the target tag twice keep a single copy.
+
+Each changed skill is saved to version history first.`,
+	Args: cobra.MinimumNArgs(1),
+	Example: `  openskill tag merge sec Security --into security
+  openskill tag merge golang go --into lang/go --alias`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if tagMergeInto == "" {
+			return UsageError(fmt.Errorf("--into is required"))
+		}
+
+		mgr := skills.NewManager()
+		target := mgr.CanonicalTag(tagMergeInto)
+		var sources []string
+		for _, a := range args {
+			if tag := mgr.CanonicalTag(a); !containsFold(sources, tag) {
+				sources = append(sources, tag)
+			}
+		}
+
+		results, err := mgr.MergeTags(sources, target)
+		if err != nil {
+			return err
+		}
+		if err := moveTagDefinitions(sources, target, tagKeepAlias); err != nil {
+			return err
+		}
+
+		printRetagResults(results)
+		fmt.Printf("✓ Merged %s into '%s'\n", strings.Join(sources, ", "), target)
+		return nil
+	},
+}
+
+var tagAliasCmd = &cobra.Command{
+	Use:   "alias <tag> <alias>...",
+	Short: "Register aliases that resolve to a tag",
+	Args:  cobra.MinimumNArgs(2),
+	Example: `  openskill tag alias security sec secure
+  openskill tag alias lang/go golang`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry, err := skills.LoadTagRegistry()
+		if err != nil {
+			return err
+		}
+
+		tag := skills.NormalizeTag(args[0])
+		def := registry.Tags[tag]
+		if def == nil {
+			def = &core.TagDefinition{}
+			registry.Tags[tag] = def
+		}
+
+		for _, a := range args[1:] {
+			alias := skills.NormalizeTag(a)
+			if alias == tag {
+				return fmt.Errorf("'%s' cannot be an alias of itself", alias)
+			}
+			if _, isTag := registry.Tags[alias]; isTag {
+				return fmt.Errorf("'%s' is already a registered tag", alias)
+			}
+			for name, other := range registry.Tags {
+				if other != nil && name != tag && containsFold(other.Aliases, alias) {
+					return fmt.Errorf("'%s' is already an alias of '%s'", alias, name)
+				}
+			}
+			if !containsFold(def.Aliases, alias) {
+				def.Aliases = append(def.Aliases, alias)
+			}
+		}
+
+		if err := skills.SaveTagRegistry(registry); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Aliases for '%s': %s\n", tag, strings.Join(def.Aliases, ", "))
+		return nil
+	},
+}
+
+var tagUnaliasCmd = &cobra.Command{
+	Use:   "unalias <alias>...",
+	Short: "Remove tag aliases",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry, err := skills.LoadTagRegistry()
+		if err != nil {
+			return err
+		}
+
+		removed := 0
+		for _, a := range args {
+			alias := skills.NormalizeTag(a)
+			for _, def := range registry.Tags {
+				if def == nil {
+					continue
+				}
+				var kept []string
+				for _, existing := range def.Aliases {
+					if skills.NormalizeTag(existing) == alias {
+						removed++
+					} else {
+						kept = append(kept, existing)
+					}
+				}
+				def.Aliases = kept
+			}
+		}
+
+		if removed == 0 {
+			fmt.Println("None of the specified aliases exist.")
+			return nil
+		}
+
+		if err := skills.SaveTagRegistry(registry); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed %d alias(es)\n", removed)
+		return nil
+	},
+}
+
+var tagDescribeCmd = &cobra.Command{
+	Use:     "describe <tag> <description>",
+	Short:   "Set the description of a tag",
+	Args:    cobra.ExactArgs(2),
+	Example: `  openskill tag describe lang/go "Skills for Go codebases"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry, err := skills.LoadTagRegistry()
+		if err != nil {
+			return err
+		}
+
+		mgr := skills.NewManager()
+		tag := mgr.CanonicalTag(args[0])
+		def := registry.Tags[tag]
+		if def == nil {
+			def = &core.TagDefinition{}
+			registry.Tags[tag] = def
+		}
+		def.Description = args[1]
+
+		if err := skills.SaveTagRegistry(registry); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Described tag '%s'\n", tag)
+		return nil
+	},
+}
+
+// moveTagDefinitions folds registry entries for renamed tags into the target,
+// optionally keeping the old names as aliases
+func moveTagDefinitions(sources []string, target string, keepAlias bool) error {
+	registry, err := skills.LoadTagRegistry()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, source := range sources {
+		def := registry.Tags[source]
+		if def == nil && !keepAlias {
+			continue
+		}
+
+		targetDef := registry.Tags[target]
+		if targetDef == nil {
+			targetDef = &core.TagDefinition{}
+			registry.Tags[target] = targetDef
+		}
+
+		if def != nil {
+			if targetDef.Description == "" {
+				targetDef.Description = def.Description
+			}
+			for _, a := range def.Aliases {
+				if !containsFold(targetDef.Aliases, a) {
+					targetDef.Aliases = append(targetDef.Aliases, a)
+				}
+			}
+			delete(registry.Tags, source)
+		}
+
+		if keepAlias && !containsFold(targetDef.Aliases, source) {
+			targetDef.Aliases = append(targetDef.Aliases, source)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return skills.SaveTagRegistry(registry)
+}
+
+func printRetagResults(results []skills.RetagResult) {
+	if len(results) == 0 {
+		fmt.Println("No skills used the tag.")
+		return
+	}
+	for _, r := range results {
+		fmt.Printf("  %s: %s → %s\n", r.Skill, strings.Join(r.OldTags, ", "), strings.Join(r.NewTags, ", "))
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	TagCmd.AddCommand(tagListCmd)
 	TagCmd.AddCommand(tagShowCmd)
 	TagCmd.AddCommand(tagAddCmd)
 	TagCmd.AddCommand(tagRemoveCmd)
+	TagCmd.AddCommand(tagRenameCmd)
+	TagCmd.AddCommand(tagMergeCmd)
+	TagCmd.AddCommand(tagAliasCmd)
+	TagCmd.AddCommand(tagUnaliasCmd)
+	TagCmd.AddCommand(tagDescribeCmd)
+
+	tagRenameCmd.Flags().BoolVar(&tagKeepAlias, "alias", false, "Keep the old tag as an alias of the new one")
+	tagMergeCmd.Flags().BoolVar(&tagKeepAlias, "alias", false, "Keep the merged tags as aliases of the target")
+	tagMergeCmd.Flags().StringVar(&tagMergeInto, "into", "", "Tag to merge into (required)")
 }
@@ -23,6 +23,20 @@ var templateListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		templates := skills.GetBuiltinTemplates()
 
+		if structuredOutput() {
+			out := []TemplateOutput{}
+			for _, t := range templates {
+				out = append(out, TemplateOutput{
+					Name:        t.Name,
+					Category:    t.Category,
+					Description: t.Description,
+					Tags:        append([]string{}, t.Skill.Tags...),
+					Rules:       len(t.Skill.Rules),
+				})
+			}
+			return printStructured(out)
+		}
+
 		if len(templates) == 0 {
 			fmt.Println("No templates available")
 			return nil
@@ -1,17 +1,28 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"openskill/pkg/eval"
 	"openskill/pkg/llm"
+	"openskill/pkg/render"
 	"openskill/pkg/skills"
+	"openskill/pkg/usage"
 
 	"github.com/spf13/cobra"
 )
 
 var testPrompt string
 var testMock bool
+var testVars []string
+var testExec bool
+var testSuite bool
+var testParallel int
+var testJudge bool
+var testRuns int
 
 var TestCmd = &cobra.Command{
 	Use:   "test <skill-name>",
@@ -19,51 +30,85 @@ var TestCmd = &cobra.Command{
 	Long: `Test a skill by running it against a sample prompt.
 
 This helps validate that a skill works as expected before using it in production.
-Use --mock to see how the skill would be applied without making an API call.`,
+Use --mock to see how the skill would be applied without making an API call.
+
+With --suite, the cases in the skill's tests.yaml are run in parallel and
+each response is checked against its expectations: contains, not_contains,
+regex, json_schema, max_length and criteria judged by the model. The
+command exits with status 1 if any case fails. Suite runs bypass the
+response cache so every run samples the model afresh.
+
+With --judge, a judge model checks every response against each of the
+skill's rules (followed, violated or not applicable, with evidence) and a
+rule-by-rule compliance scorecard is printed. The judge defaults to the
+generating provider; --judge-provider and --judge-model pick another.
+--runs samples the --prompt response several times, bypassing the
+response cache, so the scorecard reflects more than one answer.`,
 	Args: cobra.ExactArgs(1),
 	Example: `  openskill test code-review --prompt "Review this function: func add(a, b int) int { return a + b }"
-  openskill test commit-message --mock`,
+  openskill test commit-message --mock
+  openskill test code-review --suite
+  openskill test code-review --prompt "Review main.go" --judge --runs 5 --judge-provider anthropic`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		mgr := skills.NewManager()
+		if testRuns < 1 {
+			return UsageError(fmt.Errorf("--runs must be at least 1"))
+		}
+		if testJudge && testMock {
+			return UsageError(fmt.Errorf("--judge can't be combined with --mock"))
+		}
+		if testSuite {
+			if testPrompt != "" || testMock || testRuns > 1 {
+				return UsageError(fmt.Errorf("--suite can't be combined with --prompt, --mock or --runs"))
+			}
+			return runTestSuite(cmd.Context(), name)
+		}
+		if testRuns > 1 {
+			if !testJudge {
+				return UsageError(fmt.Errorf("--runs needs --judge"))
+			}
+			// Repeated requests would otherwise be answered from the cache
+			if llm.Caching == llm.CacheOn {
+				llm.Caching = llm.CacheOff
+			}
+		}
 
-		skill, err := mgr.Get(name)
+		skill, req, bundle, err := compileSkill(cmd.Context(), name, testVars, testExec, testPrompt)
 		if err != nil {
-			return fmt.Errorf("skill '%s' not found", name)
+			return err
 		}
 
-		fmt.Printf("\nTesting skill: %s\n", skill.Name)
-		fmt.Println("═══════════════════════════════════════════════════")
-
-		// Build the skill context
-		var context strings.Builder
-		context.WriteString(fmt.Sprintf("You are operating with the '%s' skill.\n\n", skill.Name))
-		context.WriteString(fmt.Sprintf("Description: %s\n\n", skill.Description))
-
-		if len(skill.Rules) > 0 {
-			context.WriteString("Rules you must follow:\n")
-			for i, rule := range skill.Rules {
-				context.WriteString(fmt.Sprintf("%d. %s\n", i+1, rule))
+		// Only the judge's scorecard has a structured form
+		quiet := testJudge && structuredOutput()
+		if !quiet {
+			fmt.Printf("\nTesting skill: %s\n", skill.Name)
+			fmt.Println("═══════════════════════════════════════════════════")
+			for _, skipped := range bundle.Skipped {
+				fmt.Printf("⚠ Context skipped: %s\n", skipped)
 			}
 		}
 
 		if testMock {
 			fmt.Println("\n[Mock Mode - No API call made]")
-			fmt.Println("\nSkill context that would be sent:")
+			fmt.Println("\nSystem prompt that would be sent:")
 			fmt.Println("───────────────────────────────────")
-			fmt.Println(context.String())
+			fmt.Println(req.System)
 
-			if testPrompt != "" {
-				fmt.Println("\nUser prompt:")
+			for _, m := range req.Messages {
+				label := "User prompt"
+				if m.Role == llm.RoleAssistant {
+					label = "Example response"
+				}
+				fmt.Printf("\n%s:\n", label)
 				fmt.Println("───────────────────────────────────")
-				fmt.Println(testPrompt)
+				fmt.Println(m.Content)
 			}
 			fmt.Println()
 			return nil
 		}
 
 		if testPrompt == "" {
-			return fmt.Errorf("--prompt is required (or use --mock for dry run)")
+			return fmt.Errorf("--prompt is required (or use --mock for dry run, --suite for tests.yaml)")
 		}
 
 		// Make actual API call
@@ -71,28 +116,217 @@ Use --mock to see how the skill would be applied without making an API call.`,
 		if !gen.IsAvailable() {
 			return fmt.Errorf("no AI provider configured. Use 'openskill config set api-key' or --mock flag")
 		}
+		ctx := usage.WithSkill(cmd.Context(), name)
+		vars, _ := render.ParseVars(testVars) // Already checked by compileSkill
+		rules := eval.ExpandRules(skill, vars)
+		var judge llm.Provider
+		if testJudge {
+			if len(rules) == 0 {
+				return fmt.Errorf("skill '%s' has no rules to judge", skill.Name)
+			}
+			if judge, err = judgeProvider(gen.Provider()); err != nil {
+				return err
+			}
+		}
 
-		fullPrompt := context.String() + "\n\nUser request:\n" + testPrompt
+		if !quiet {
+			fmt.Printf("\nRunning with %s...\n", gen.ProviderName())
+			fmt.Println("───────────────────────────────────")
+		}
 
-		fmt.Printf("\nRunning with %s...\n", gen.ProviderName())
-		fmt.Println("───────────────────────────────────")
+		card := eval.NewScorecard(rules)
+		for run := 1; run <= testRuns; run++ {
+			var resp *llm.Response
+			if quiet {
+				resp, err = gen.Provider().Chat(ctx, req)
+			} else {
+				if testRuns > 1 {
+					fmt.Printf("\nResponse %d/%d:\n", run, testRuns)
+				} else {
+					fmt.Println("\nResponse:")
+				}
+				fmt.Println("───────────────────────────────────")
 
-		// Use the provider directly for custom prompt
-		response, err := gen.Provider().Generate(fullPrompt)
-		if err != nil {
-			return fmt.Errorf("API call failed: %w", err)
-		}
+				// Tokens print as they arrive
+				resp, err = llm.ChatStream(ctx, gen.Provider(), req, streamPrinter())
+				fmt.Println()
+			}
+			if err != nil {
+				return fmt.Errorf("API call failed: %w", err)
+			}
+			if !quiet {
+				fmt.Println()
+			}
 
-		fmt.Println("\nResponse:")
-		fmt.Println("───────────────────────────────────")
-		fmt.Println(response)
-		fmt.Println()
+			if testJudge {
+				verdicts, err := eval.JudgeRules(ctx, judge, skill.Name, testPrompt, resp.Content, rules)
+				if err != nil {
+					return err
+				}
+				card.Add(verdicts)
+			}
+		}
 
+		if !testJudge {
+			return nil
+		}
+		if quiet {
+			return printStructured(JudgeOutput{Skill: skill.Name, Provider: providerLabel(gen.Provider()), Judge: providerLabel(judge), Scorecard: card})
+		}
+		printScorecard(card, judge)
 		return nil
 	},
 }
 
+// runTestSuite runs the cases in a skill's tests.yaml
+func runTestSuite(ctx context.Context, name string) error {
+	mgr := skills.NewManager()
+	if !mgr.Exists(name) {
+		return notFoundError(name)
+	}
+	suite, err := loadSuite(mgr, name)
+	if err != nil {
+		return err
+	}
+	vars, err := render.ParseVars(testVars)
+	if err != nil {
+		return UsageError(err)
+	}
+	skill, err := mgr.Resolve(name)
+	if err != nil {
+		return err
+	}
+	bundle := render.Gather(ctx, skill.Context, render.GatherOptions{Exec: testExec})
+
+	// A suite samples the model afresh each run; cached replies would
+	// repeat the last run's responses with meaningless latencies
+	if llm.Caching == llm.CacheOn {
+		llm.Caching = llm.CacheOff
+	}
+	provider := llm.GetProvider()
+	if !provider.IsConfigured() {
+		return fmt.Errorf("no AI provider configured. Use 'openskill config set api-key'")
+	}
+
+	runner := &eval.Runner{Skill: skill, Context: bundle, Vars: vars, Provider: provider, Parallel: testParallel, JudgeRules: testJudge}
+	if testJudge {
+		if runner.Judge, err = judgeProvider(provider); err != nil {
+			return err
+		}
+	}
+	if !structuredOutput() {
+		fmt.Printf("\nTest suite: %s (%d cases, %s)\n", skill.Name, len(suite.Cases), provider.Name())
+		fmt.Println("═══════════════════════════════════════════════════")
+		for _, skipped := range bundle.Skipped {
+			fmt.Printf("⚠ Context skipped: %s\n", skipped)
+		}
+	}
+	results := runner.Run(usage.WithSkill(ctx, name), suite)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	failed := 0
+	var card *eval.Scorecard
+	if testJudge && len(skill.Rules) > 0 {
+		card = eval.NewScorecard(eval.ExpandRules(skill, vars))
+	}
+	for _, r := range results {
+		if !r.Pass {
+			failed++
+		}
+		if card != nil && r.Rules != nil {
+			card.Add(r.Rules)
+		}
+	}
+
+	if structuredOutput() {
+		if err := printStructured(SuiteOutput{
+			Skill:     skill.Name,
+			Provider:  provider.Name(),
+			Passed:    len(results) - failed,
+			Failed:    failed,
+			Cases:     results,
+			Scorecard: card,
+		}); err != nil {
+			return err
+		}
+		return reportedError(suiteError(failed))
+	}
+
+	for _, r := range results {
+		printCaseResult(r)
+	}
+	fmt.Println("───────────────────────────────────────────────────")
+	fmt.Printf("%d passed, %d failed\n\n", len(results)-failed, failed)
+	if card != nil {
+		printScorecard(card, runner.Judge)
+	}
+	return suiteError(failed)
+}
+
+// loadSuite loads a skill's tests.yaml, explaining how to add one if it's
+// missing
+func loadSuite(mgr *skills.Manager, name string) (*eval.Suite, error) {
+	suite, err := eval.LoadSuite(mgr.GetSkillDir(name))
+	if errors.Is(err, eval.ErrNoSuite) {
+		return nil, fmt.Errorf("skill '%s' has no %s; see 'openskill test --help'", name, eval.SuiteFile)
+	}
+	return suite, err
+}
+
+func printCaseResult(r eval.CaseResult) {
+	if r.Pass {
+		fmt.Printf("✓ %s (%dms)\n", r.Name, r.DurationMS)
+		return
+	}
+	fmt.Printf("❌ %s (%dms)\n", r.Name, r.DurationMS)
+	if r.Error != "" {
+		fmt.Printf("    error: %s\n", r.Error)
+		return
+	}
+	for _, c := range r.Checks {
+		if c.Pass {
+			continue
+		}
+		fmt.Printf("    ✗ %s\n", c.Check)
+		printDiffSide("-", c.Expected)
+		printDiffSide("+", c.Actual)
+	}
+}
+
+// maxDiffLines bounds each side of a failed check's diff
+const maxDiffLines = 12
+
+func printDiffSide(op, text string) {
+	if text == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if i == maxDiffLines {
+			fmt.Printf("      %s … %d more lines\n", op, len(lines)-maxDiffLines)
+			break
+		}
+		fmt.Printf("      %s %s\n", op, line)
+	}
+}
+
+func suiteError(failed int) error {
+	if failed == 0 {
+		return nil
+	}
+	return newCodedError(CodeTestsFailed, fmt.Errorf("%d test case(s) failed", failed))
+}
+
 func init() {
 	TestCmd.Flags().StringVarP(&testPrompt, "prompt", "p", "", "Test prompt to run against the skill")
 	TestCmd.Flags().BoolVar(&testMock, "mock", false, "Mock mode - show skill context without API call")
+	TestCmd.Flags().StringArrayVar(&testVars, "var", nil, "Set a skill variable (name=value, repeatable)")
+	TestCmd.Flags().BoolVar(&testExec, "exec", false, "Run the skill's context commands and fetch its context URLs")
+	TestCmd.Flags().BoolVar(&testSuite, "suite", false, "Run the cases in the skill's tests.yaml")
+	TestCmd.Flags().IntVar(&testParallel, "parallel", eval.DefaultParallel, "Cases to run at once with --suite")
+	TestCmd.Flags().BoolVar(&testJudge, "judge", false, "Score each response's compliance with the skill's rules")
+	TestCmd.Flags().IntVar(&testRuns, "runs", 1, "Responses to sample for --prompt with --judge")
+	addJudgeFlags(TestCmd)
 }
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"openskill/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+// Budget is set by the global --budget flag, in USD
+var Budget float64
+
+// CheckUsageFlags applies --budget and labels AI calls with the running command
+func CheckUsageFlags(cmd *cobra.Command) error {
+	if Budget < 0 {
+		return UsageError(fmt.Errorf("--budget must not be negative"))
+	}
+	usage.SetBudget(Budget)
+
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	cmd.SetContext(usage.WithCommand(cmd.Context(), name))
+	return nil
+}
+
+var (
+	usageSince string
+	usageBy    string
+)
+
+var UsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report AI token usage and cost",
+	Long: `Report tokens and estimated cost of AI calls recorded in
+~/.openskill/usage.jsonl, grouped by day, command, provider, model or skill.
+
+Costs use built-in list prices per million tokens. Override or add models
+in ~/.openskill/config.yaml:
+
+  prices:
+    my-finetune:
+      input: 0.30
+      output: 1.20
+
+Cached and replayed responses are counted as calls but cost nothing.`,
+	Args: cobra.NoArgs,
+	Example: `  openskill usage
+  openskill usage --by skill --since 7d
+  openskill usage --by command --since 2024-06-01`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseSince(usageSince)
+		if err != nil {
+			return UsageError(err)
+		}
+		entries, err := usage.ReadLedger(since)
+		if err != nil {
+			return err
+		}
+		groups, total, err := usage.Summarize(entries, usageBy)
+		if err != nil {
+			return UsageError(err)
+		}
+
+		if structuredOutput() {
+			return printStructured(UsageOutput{Since: since, By: usageBy, Groups: groups, Total: total})
+		}
+
+		fmt.Println()
+		fmt.Printf("  AI Usage since %s\n", since.Format("2006-01-02"))
+		fmt.Println("  ═══════════════════════════════════════════════════════════════")
+		fmt.Println()
+		if len(entries) == 0 {
+			fmt.Println("  No AI calls recorded.")
+			fmt.Println()
+			return nil
+		}
+
+		fmt.Printf("  %-24s %6s %12s %12s %10s\n", strings.ToUpper(usageBy), "CALLS", "PROMPT", "COMPLETION", "COST")
+		fmt.Println("  ───────────────────────────────────────────────────────────────────")
+		for _, g := range groups {
+			printUsageRow(g)
+		}
+		fmt.Println("  ───────────────────────────────────────────────────────────────────")
+		printUsageRow(total)
+		fmt.Println()
+
+		if total.CachedCalls > 0 {
+			fmt.Printf("  %d call(s) served from cache or fixtures at no cost\n", total.CachedCalls)
+		}
+		if total.UnpricedCalls > 0 {
+			fmt.Printf("  ⚠ %d call(s) used models without a known price; add them under prices in ~/.openskill/config.yaml\n", total.UnpricedCalls)
+		}
+		if total.CachedCalls > 0 || total.UnpricedCalls > 0 {
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+func printUsageRow(t usage.Total) {
+	cost := fmt.Sprintf("$%.4f", t.Cost)
+	if t.UnpricedCalls > 0 {
+		cost += "*"
+	}
+	fmt.Printf("  %-24s %6d %12d %12d %10s\n", truncate(t.Key, 24), t.Calls, t.PromptTokens, t.CompletionTokens, cost)
+}
+
+// parseSince accepts a number of days ("7d"), a duration ("12h") or a date
+func parseSince(s string) (time.Time, error) {
+	now := time.Now()
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since '%s' (use 7d, 12h or 2024-06-01)", s)
+}
+
+func init() {
+	UsageCmd.Flags().StringVar(&usageSince, "since", "30d", "Only include calls since this many days (7d), hours (12h) or a date (2024-06-01)")
+	UsageCmd.Flags().StringVar(&usageBy, "by", "day", "Group by "+strings.Join(usage.Groupings, ", "))
+}
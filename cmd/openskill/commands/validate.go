@@ -1,145 +1,488 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"openskill/pkg/lint"
 	"openskill/pkg/skills"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
+var (
+	validateListRules    bool
+	validateExplain      string
+	validateAll          bool
+	validateChangedSince string
+	validateStrict       bool
+	validateSARIF        string
+	validateJUnit        string
+)
+
 var ValidateCmd = &cobra.Command{
-	Use:   "validate <name>",
+	Use:   "validate [name]",
 	Short: "Validate a skill's YAML structure and rules",
 	Long: `Validate a skill file to ensure it has correct YAML syntax
 and follows OpenSkill conventions.
 
-Checks performed:
-  • YAML syntax validation
+Checks are lint rules with stable IDs (see --list-rules), including:
+  • Frontmatter syntax and schema
   • Required fields (name, description)
-  • Rule format and content
-  • Best practices recommendations`,
-	Args: cobra.ExactArgs(1),
+  • Vague language and duplicate rules
+  • References to skills that don't exist
+
+Rules can be disabled or tuned in .openskill-lint.yaml, and silenced
+inline in SKILL.md:
+
+  <!-- openskill-lint-disable-next-line vague-language -->
+  - Follow best practices for naming
+
+Exit status is 0 when every skill passes, 1 when any skill has errors
+(or warnings with --strict), and 2 for usage mistakes.`,
+	Example: `  openskill validate code-review
+  openskill validate --all --strict
+  openskill validate --changed-since origin/main --sarif lint.sarif --junit lint.xml
+  openskill validate --list-rules
+  openskill validate --explain vague-language`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runValidate,
 }
 
-type ValidationResult struct {
-	Errors   []string
-	Warnings []string
+func init() {
+	ValidateCmd.Flags().BoolVar(&validateListRules, "list-rules", false, "List available lint rules")
+	ValidateCmd.Flags().StringVar(&validateExplain, "explain", "", "Show documentation for a lint rule")
+	ValidateCmd.Flags().BoolVar(&validateAll, "all", false, "Validate every skill")
+	ValidateCmd.Flags().StringVar(&validateChangedSince, "changed-since", "", "Validate skills changed since a git ref")
+	ValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Treat warnings as failures")
+	ValidateCmd.Flags().StringVar(&validateSARIF, "sarif", "", "Write a SARIF report to a file")
+	ValidateCmd.Flags().StringVar(&validateJUnit, "junit", "", "Write a JUnit XML report to a file")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
-	name := args[0]
+	if validateListRules {
+		return printLintRules()
+	}
+	if validateExplain != "" {
+		return explainLintRule(validateExplain)
+	}
+
+	modes := 0
+	for _, set := range []bool{len(args) == 1, validateAll, validateChangedSince != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return UsageError(fmt.Errorf("validate needs exactly one of: a skill name, --all or --changed-since"))
+	}
+
 	mgr := skills.NewManager()
 
-	// Try to load the skill
-	skill, err := mgr.Get(name)
+	cfg, err := lint.LoadConfig(lint.ConfigFile)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("skill '%s' not found", name)
+		return newCodedError(CodeConfig, err)
+	}
+
+	if len(args) == 1 {
+		return validateOne(cmd, mgr, cfg, args[0])
+	}
+
+	var names []string
+	if validateAll {
+		names, err = mgr.Dirs()
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+	} else {
+		names, err = changedSkills(validateChangedSince)
+		if err != nil {
+			return err
 		}
-		// YAML parsing error
-		fmt.Printf("\n  ❌ Validation Failed: %s\n\n", name)
-		fmt.Println("  YAML Syntax Error:")
-		fmt.Printf("  └─ %v\n\n", err)
-		return nil
 	}
+	return validateMany(cmd, mgr, cfg, names)
+}
 
-	result := validateSkill(skill.Name, skill.Description, skill.Rules)
+// validateOne lints a single skill and prints a detailed report
+func validateOne(cmd *cobra.Command, mgr *skills.Manager, cfg *lint.Config, name string) error {
+	doc, findings, err := lintSkill(mgr, name, cfg)
+	if err != nil {
+		return err
+	}
+	result := lint.FileResult{Skill: name, Path: doc.Path, Findings: findings}
+	if err := writeLintReports(cmd, []lint.FileResult{result}); err != nil {
+		return err
+	}
+	errs, warnings, infos := splitFindings(findings)
+	failed := result.Failed(validateStrict)
+
+	if structuredOutput() {
+		if err := printStructured(validateOutput(result)); err != nil {
+			return err
+		}
+		if failed {
+			return reportedError(validationError([]string{name}))
+		}
+		return nil
+	}
 
 	// Print results
 	fmt.Println()
-	if len(result.Errors) == 0 && len(result.Warnings) == 0 {
+	if len(findings) == 0 {
 		fmt.Printf("  ✓ Skill '%s' is valid\n\n", name)
-		printSkillSummary(skill.Name, skill.Description, skill.Rules)
+		if doc.Skill != nil {
+			printSkillSummary(doc.Skill.Name, doc.Skill.Description, doc.Skill.Rules)
+		}
 		return nil
 	}
 
-	if len(result.Errors) > 0 {
+	if failed {
 		fmt.Printf("  ❌ Validation Failed: %s\n\n", name)
-		fmt.Println("  Errors:")
-		for _, e := range result.Errors {
-			fmt.Printf("  └─ %s\n", e)
-		}
-		fmt.Println()
+	}
+	if len(errs) > 0 {
+		printFindingList("Errors:", errs)
 	}
 
-	if len(result.Warnings) > 0 {
-		if len(result.Errors) == 0 {
+	if len(warnings) > 0 {
+		if !failed {
 			fmt.Printf("  ⚠ Validation Passed with Warnings: %s\n\n", name)
 		}
-		fmt.Println("  Warnings:")
-		for _, w := range result.Warnings {
-			fmt.Printf("  └─ %s\n", w)
+		printFindingList("Warnings:", warnings)
+	}
+
+	if len(infos) > 0 {
+		if len(errs) == 0 && len(warnings) == 0 {
+			fmt.Printf("  ✓ Skill '%s' is valid\n\n", name)
 		}
-		fmt.Println()
+		printFindingList("Notes:", infos)
 	}
 
-	if len(result.Errors) == 0 {
-		printSkillSummary(skill.Name, skill.Description, skill.Rules)
+	if !failed && doc.Skill != nil {
+		printSkillSummary(doc.Skill.Name, doc.Skill.Description, doc.Skill.Rules)
 	}
 
+	if failed {
+		return validationError([]string{name})
+	}
 	return nil
 }
 
-func validateSkill(name, description string, rules []string) ValidationResult {
-	result := ValidationResult{}
+// validateMany lints several skills and prints a compact report
+func validateMany(cmd *cobra.Command, mgr *skills.Manager, cfg *lint.Config, names []string) error {
+	var results []lint.FileResult
+	var failed []string
+	for _, name := range names {
+		doc, findings, err := lintSkill(mgr, name, cfg)
+		if err != nil {
+			return err
+		}
+		result := lint.FileResult{Skill: name, Path: doc.Path, Findings: findings}
+		results = append(results, result)
+		if result.Failed(validateStrict) {
+			failed = append(failed, name)
+		}
+	}
 
-	// Check required fields
-	if name == "" {
-		result.Errors = append(result.Errors, "Missing required field: name")
-	} else {
-		// Check name format
-		if strings.Contains(name, " ") {
-			result.Warnings = append(result.Warnings, "Skill name contains spaces - consider using hyphens (e.g., 'code-review')")
+	if err := writeLintReports(cmd, results); err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		out := ValidateReportOutput{Strict: validateStrict, Checked: len(results), Failed: len(failed), Skills: []ValidateOutput{}}
+		for _, r := range results {
+			out.Skills = append(out.Skills, validateOutput(r))
 		}
-		if len(name) > 50 {
-			result.Warnings = append(result.Warnings, "Skill name is very long - consider a shorter, more memorable name")
+		if err := printStructured(out); err != nil {
+			return err
 		}
+		return reportedError(validationError(failed))
 	}
 
-	if description == "" {
-		result.Errors = append(result.Errors, "Missing required field: description")
-	} else {
-		if len(description) < 10 {
-			result.Warnings = append(result.Warnings, "Description is very short - add more detail for clarity")
+	fmt.Println()
+	if len(results) == 0 {
+		if validateChangedSince != "" {
+			fmt.Printf("  No skills changed since %s\n\n", validateChangedSince)
+		} else {
+			fmt.Printf("  No skills found\n\n")
 		}
-		if len(description) > 500 {
-			result.Warnings = append(result.Warnings, "Description is very long - consider being more concise")
+		return nil
+	}
+
+	fmt.Printf("  Validating %d skill(s)\n", len(results))
+	fmt.Println("  ═══════════════════════")
+	withWarnings := 0
+	for _, r := range results {
+		errs, warnings, infos := splitFindings(r.Findings)
+		switch {
+		case r.Failed(validateStrict):
+			fmt.Printf("  ❌ %s %s\n", r.Skill, findingCounts(errs, warnings))
+		case len(warnings) > 0:
+			withWarnings++
+			fmt.Printf("  ⚠ %s %s\n", r.Skill, findingCounts(errs, warnings))
+		default:
+			fmt.Printf("  ✓ %s\n", r.Skill)
+		}
+		for _, line := range formatFindings(append(append(errs, warnings...), infos...)) {
+			fmt.Printf("     └─ %s\n", line)
 		}
 	}
 
-	// Check rules
-	if len(rules) == 0 {
-		result.Warnings = append(result.Warnings, "No rules defined - skills work better with specific behavioral rules")
-	} else {
-		for i, rule := range rules {
-			if rule == "" {
-				result.Errors = append(result.Errors, fmt.Sprintf("Rule %d is empty", i+1))
-				continue
-			}
-			if len(rule) < 10 {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("Rule %d is very short - be more specific", i+1))
-			}
-			if len(rule) > 500 {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("Rule %d is very long - consider breaking into multiple rules", i+1))
-			}
-			// Check for common anti-patterns
-			lowerRule := strings.ToLower(rule)
-			if strings.HasPrefix(lowerRule, "be good") || strings.HasPrefix(lowerRule, "be nice") {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("Rule %d is vague - use specific, actionable instructions", i+1))
-			}
-		}
-
-		if len(rules) > 20 {
-			result.Warnings = append(result.Warnings, "Many rules defined - consider consolidating related rules")
-		}
-	}
-
-	return result
+	fmt.Println("  ───────────────────────")
+	fmt.Printf("  %d checked, %d failed, %d with warnings\n\n", len(results), len(failed), withWarnings)
+
+	return validationError(failed)
+}
+
+func findingCounts(errs, warnings []lint.Finding) string {
+	var parts []string
+	if len(errs) > 0 {
+		parts = append(parts, fmt.Sprintf("%d error(s)", len(errs)))
+	}
+	if len(warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d warning(s)", len(warnings)))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func validateOutput(r lint.FileResult) ValidateOutput {
+	errs, warnings, _ := splitFindings(r.Findings)
+	return ValidateOutput{
+		Skill:    r.Skill,
+		Valid:    !r.Failed(validateStrict),
+		Errors:   formatFindings(errs),
+		Warnings: formatFindings(warnings),
+		Findings: append([]lint.Finding{}, r.Findings...),
+	}
+}
+
+// changedSkills returns the skills whose directory differs from ref,
+// including uncommitted and untracked changes. Deleted skills are skipped.
+func changedSkills(ref string) ([]string, error) {
+	diff, err := getGitOutput(".", "diff", "--name-only", "--relative", ref, "--", skills.SkillsDir)
+	if err != nil {
+		return nil, newCodedError(CodeGit, fmt.Errorf("git diff against '%s' failed: %s", ref, gitErrorText(err)))
+	}
+	untracked, err := getGitOutput(".", "ls-files", "--others", "--exclude-standard", "--", skills.SkillsDir)
+	if err != nil {
+		return nil, newCodedError(CodeGit, fmt.Errorf("git ls-files failed: %s", gitErrorText(err)))
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, path := range strings.Split(diff+"\n"+untracked, "\n") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		rel, err := filepath.Rel(skills.SkillsDir, path)
+		if err != nil {
+			continue
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 2 || parts[0] == ".." || parts[0] == ".history" || seen[parts[0]] {
+			continue
+		}
+		seen[parts[0]] = true
+		if _, err := os.Stat(filepath.Join(skills.SkillsDir, parts[0], "SKILL.md")); err == nil {
+			names = append(names, parts[0])
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// gitErrorText prefers git's own stderr message over "exit status N"
+func gitErrorText(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return strings.TrimSpace(string(exitErr.Stderr))
+	}
+	return err.Error()
+}
+
+// writeLintReports writes the --sarif and --junit reports if requested
+func writeLintReports(cmd *cobra.Command, results []lint.FileResult) error {
+	if validateSARIF != "" {
+		err := writeReportFile(validateSARIF, func(w io.Writer) error {
+			return lint.WriteSARIF(w, cmd.Root().Version, results)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write SARIF report: %w", err)
+		}
+		progressf("  Wrote SARIF report to %s\n", validateSARIF)
+	}
+	if validateJUnit != "" {
+		err := writeReportFile(validateJUnit, func(w io.Writer) error {
+			return lint.WriteJUnit(w, results, validateStrict)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+		progressf("  Wrote JUnit report to %s\n", validateJUnit)
+	}
+	return nil
+}
+
+func writeReportFile(path string, write func(w io.Writer) error) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// lintSkill reads a skill's SKILL.md and runs the lint rules over it
+func lintSkill(mgr *skills.Manager, name string, cfg *lint.Config) (*lint.Document, []lint.Finding, error) {
+	path := filepath.Join(mgr.GetSkillDir(name), "SKILL.md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, notFoundError(name)
+		}
+		return nil, nil, fmt.Errorf("failed to read skill: %w", err)
+	}
+
+	doc := lint.ParseDocument(path, data)
+	findings := lint.Lint(doc, lint.Options{
+		Config: cfg,
+		SkillExists: func(ref string) bool {
+			_, err := os.Stat(filepath.Join(mgr.GetSkillDir(ref), "SKILL.md"))
+			return err == nil
+		},
+	})
+	return doc, findings, nil
+}
+
+// splitFindings groups findings by severity
+func splitFindings(findings []lint.Finding) (errs, warnings, infos []lint.Finding) {
+	for _, f := range findings {
+		switch f.Severity {
+		case lint.SeverityError:
+			errs = append(errs, f)
+		case lint.SeverityWarning:
+			warnings = append(warnings, f)
+		default:
+			infos = append(infos, f)
+		}
+	}
+	return errs, warnings, infos
+}
+
+// formatFindings renders findings as "L12:3 message [rule-id]"
+func formatFindings(findings []lint.Finding) []string {
+	out := make([]string, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, fmt.Sprintf("L%d:%d %s [%s]", f.Line, f.Column, f.Message, f.RuleID))
+	}
+	return out
+}
+
+func printFindingList(title string, findings []lint.Finding) {
+	fmt.Printf("  %s\n", title)
+	for _, line := range formatFindings(findings) {
+		fmt.Printf("  └─ %s\n", line)
+	}
+	fmt.Println()
+}
+
+// validationError returns a coded error naming the skills that failed
+func validationError(failed []string) error {
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return newCodedError(CodeValidationFailed, fmt.Errorf("skill '%s' failed validation", failed[0]))
+	}
+	return newCodedError(CodeValidationFailed, fmt.Errorf("%d skills failed validation: %s", len(failed), strings.Join(failed, ", ")))
+}
+
+func printLintRules() error {
+	cfg, err := lint.LoadConfig(lint.ConfigFile)
+	if err != nil {
+		return newCodedError(CodeConfig, err)
+	}
+
+	rules := lint.Rules()
+	if structuredOutput() {
+		out := make([]LintRuleOutput, 0, len(rules))
+		for _, r := range rules {
+			out = append(out, lintRuleOutput(r, cfg))
+		}
+		return printStructured(out)
+	}
+
+	fmt.Println("\n  Lint Rules:")
+	fmt.Println("  ═══════════")
+	for _, r := range rules {
+		o := lintRuleOutput(r, cfg)
+		severity := o.Severity
+		if !o.Enabled {
+			severity = "off"
+		}
+		fmt.Printf("  %-20s %-8s %s\n", r.ID, severity, r.Summary)
+	}
+	fmt.Printf("\n  Use 'openskill validate --explain <rule>' for details.\n\n")
+	return nil
+}
+
+func explainLintRule(id string) error {
+	rule, ok := lint.LookupRule(id)
+	if !ok {
+		return UsageError(fmt.Errorf("unknown lint rule '%s' (see 'openskill validate --list-rules')", id))
+	}
+	cfg, err := lint.LoadConfig(lint.ConfigFile)
+	if err != nil {
+		return newCodedError(CodeConfig, err)
+	}
+
+	o := lintRuleOutput(rule, cfg)
+	if structuredOutput() {
+		return printStructured(o)
+	}
+
+	fmt.Printf("\n  %s\n", rule.ID)
+	fmt.Println("  " + strings.Repeat("─", len(rule.ID)))
+	fmt.Printf("  %s\n\n", rule.Summary)
+	for _, line := range strings.Split(rule.Docs, "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Printf("\n  Default severity: %s\n", rule.DefaultSeverity)
+	if !o.Enabled {
+		fmt.Printf("  Disabled in %s\n", lint.ConfigFile)
+	} else if o.Severity != string(rule.DefaultSeverity) {
+		fmt.Printf("  Configured severity: %s\n", o.Severity)
+	}
+	fmt.Println()
+	return nil
+}
+
+// lintRuleOutput describes a rule together with its effective configuration
+func lintRuleOutput(r lint.Rule, cfg *lint.Config) LintRuleOutput {
+	sev := cfg.Severity(r)
+	return LintRuleOutput{
+		ID:              r.ID,
+		Summary:         r.Summary,
+		Docs:            r.Docs,
+		DefaultSeverity: string(r.DefaultSeverity),
+		Severity:        string(sev),
+		Enabled:         sev != "",
+	}
 }
 
 func printSkillSummary(name, description string, rules []string) {
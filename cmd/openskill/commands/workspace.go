@@ -65,6 +65,9 @@ var workspaceShowCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if structuredOutput() {
+			return printStructured(WorkspaceOutput{Configured: workspace != nil, Workspace: workspace})
+		}
 		if workspace == nil {
 			fmt.Println("No workspace configured.")
 			fmt.Println("Use 'openskill workspace init' to create one.")
@@ -85,7 +88,7 @@ var workspaceShowCmd = &cobra.Command{
 			}
 			fmt.Println()
 		} else {
-			fmt.Println("No skills enabled.\n")
+			fmt.Print("No skills enabled.\n\n")
 		}
 
 		if len(workspace.Groups) > 0 {
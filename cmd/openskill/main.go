@@ -1,8 +1,10 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"openskill/cmd/openskill/commands"
 
@@ -24,9 +26,30 @@ Quick Start:
   openskill add "my-skill"    # Create a new skill with AI
   openskill list              # View all skills`,
 	Version: "0.3.0",
+	// main prints errors itself so structured output can format them
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments parsed fine; runtime failures don't need the usage text
+		cmd.SilenceUsage = true
+		if err := commands.CheckCacheFlags(); err != nil {
+			return err
+		}
+		if err := commands.CheckUsageFlags(cmd); err != nil {
+			return err
+		}
+		return commands.CheckOutputFormat()
+	},
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&commands.OutputFormat, "output", "text", "Output format for supported commands (text, json, yaml)")
+	rootCmd.PersistentFlags().BoolVar(&commands.NoCache, "no-cache", false, "Always call the AI provider, bypassing the response cache")
+	rootCmd.PersistentFlags().Float64Var(&commands.Budget, "budget", 0, "Stop making AI calls once this many USD have been spent (0 = no limit)")
+	rootCmd.PersistentFlags().BoolVar(&commands.CacheOnly, "cache-only", false, "Only serve AI responses from the cache; fail instead of calling the provider")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return commands.UsageError(err)
+	})
+
 	// Core commands
 	rootCmd.AddCommand(commands.InitCmd)
 	rootCmd.AddCommand(commands.AddCmd)
@@ -36,6 +59,7 @@ func init() {
 	rootCmd.AddCommand(commands.RemoveCmd)
 	rootCmd.AddCommand(commands.ValidateCmd)
 	rootCmd.AddCommand(commands.ConfigCmd)
+	rootCmd.AddCommand(commands.DoctorCmd)
 
 	// Version history
 	rootCmd.AddCommand(commands.HistoryCmd)
@@ -52,10 +76,18 @@ func init() {
 
 	// Testing
 	rootCmd.AddCommand(commands.TestCmd)
+	rootCmd.AddCommand(commands.ChatCmd)
+	rootCmd.AddCommand(commands.RunCmd)
+	rootCmd.AddCommand(commands.EvalCmd)
+	rootCmd.AddCommand(commands.BenchCmd)
 
 	// AI-powered
 	rootCmd.AddCommand(commands.ImproveCmd)
 	rootCmd.AddCommand(commands.ExplainCmd)
+	rootCmd.AddCommand(commands.CacheCmd)
+	rootCmd.AddCommand(commands.UsageCmd)
+	rootCmd.AddCommand(commands.PromptsCmd)
+	rootCmd.AddCommand(commands.ModelsCmd)
 
 	// Organization
 	rootCmd.AddCommand(commands.TagCmd)
@@ -67,8 +99,16 @@ func init() {
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	// Ctrl-C cancels the command's context, aborting in-flight API requests
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	if err != nil && ctx.Err() != nil {
+		err = commands.InterruptedError(err)
+	}
+	stop()
+
+	if err != nil {
+		commands.PrintError(err)
+		os.Exit(commands.ExitCode(err))
 	}
 }
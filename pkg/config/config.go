@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
 	// Provider selection
-	Provider string `yaml:"provider,omitempty"` // groq, openai, anthropic, ollama
+	Provider  string   `yaml:"provider,omitempty"`  // groq, openai, anthropic, ollama
+	Providers []string `yaml:"providers,omitempty"` // Fallback chain tried in order; takes precedence over provider
 
 	// Legacy Groq key (for backwards compatibility)
 	GroqAPIKey string `yaml:"groq_api_key,omitempty"`
@@ -29,6 +33,50 @@ type Config struct {
 
 	// Ollama settings
 	OllamaEndpoint string `yaml:"ollama_endpoint,omitempty"` // Custom Ollama endpoint
+
+	// Retry settings for rate-limited or failing API requests
+	MaxRetries   *int   `yaml:"max_retries,omitempty"`    // Retries after the first attempt (0 disables)
+	RetryMaxWait string `yaml:"retry_max_wait,omitempty"` // Total time to spend waiting, e.g. "60s"
+
+	// Response cache under ~/.openskill/cache
+	CacheTTL   string `yaml:"cache_ttl,omitempty"`    // How long entries stay valid, e.g. "24h"; "0" disables the cache
+	CacheMaxMB int    `yaml:"cache_max_mb,omitempty"` // Size limit; least recently used entries go first
+
+	// Per-model prices in USD per million tokens, overriding the built-in table
+	Prices map[string]ModelPrice `yaml:"prices,omitempty"`
+
+	// OpenAI-compatible servers (vLLM, LM Studio, OpenRouter, Azure OpenAI),
+	// keyed by the name used to select them with `provider`
+	CustomProviders map[string]CustomProvider `yaml:"custom_providers,omitempty"`
+
+	// External provider executables speaking the plugin protocol over stdio
+	Plugins map[string]Plugin `yaml:"plugins,omitempty"`
+}
+
+// ModelPrice is the cost of a model in USD per million tokens
+type ModelPrice struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// Plugin configures an external provider executable. Command defaults to
+// openskill-provider-<name> on PATH.
+type Plugin struct {
+	Command string            `yaml:"command,omitempty"` // Executable name or path
+	Args    []string          `yaml:"args,omitempty"`    // Extra arguments
+	Env     map[string]string `yaml:"env,omitempty"`     // Extra environment; ${VAR} is expanded
+	Model   string            `yaml:"model,omitempty"`   // Passed to the plugin with each request
+}
+
+// CustomProvider configures an OpenAI-compatible chat completions API.
+// ${VAR} references in APIKey and Headers are expanded from the environment.
+type CustomProvider struct {
+	BaseURL    string            `yaml:"base_url"`              // e.g. http://localhost:8000/v1
+	APIKey     string            `yaml:"api_key,omitempty"`     // Optional; sent as a bearer token
+	Model      string            `yaml:"model,omitempty"`       // Model name sent with each request
+	Headers    map[string]string `yaml:"headers,omitempty"`     // Extra request headers
+	Deployment string            `yaml:"deployment,omitempty"`  // Azure OpenAI deployment; enables Azure routing
+	APIVersion string            `yaml:"api_version,omitempty"` // Azure OpenAI api-version
 }
 
 func configDir() (string, error) {
@@ -39,6 +87,11 @@ func configDir() (string, error) {
 	return filepath.Join(home, ".openskill"), nil
 }
 
+// Dir returns the directory holding openskill's config and caches
+func Dir() (string, error) {
+	return configDir()
+}
+
 func configPath() (string, error) {
 	dir, err := configDir()
 	if err != nil {
@@ -100,13 +153,37 @@ func GetProvider() string {
 	}
 
 	cfg, err := Load()
-	if err != nil || cfg.Provider == "" {
+	if err != nil {
+		return "groq"
+	}
+	if cfg.Provider == "" {
+		if len(cfg.Providers) > 0 {
+			return strings.ToLower(cfg.Providers[0])
+		}
 		return "groq" // Default to groq for backwards compatibility
 	}
 
 	return strings.ToLower(cfg.Provider)
 }
 
+// GetProviderChain returns the providers to try in order. OPENSKILL_PROVIDER
+// selects a single provider; otherwise the providers list is used when set.
+func GetProviderChain() []string {
+	if provider := os.Getenv("OPENSKILL_PROVIDER"); provider != "" {
+		return []string{strings.ToLower(provider)}
+	}
+
+	cfg, err := Load()
+	if err != nil || len(cfg.Providers) == 0 {
+		return []string{GetProvider()}
+	}
+	chain := make([]string, len(cfg.Providers))
+	for i, p := range cfg.Providers {
+		chain[i] = strings.ToLower(p)
+	}
+	return chain
+}
+
 // GetProviderAPIKey returns the API key for a specific provider
 func GetProviderAPIKey(provider string) string {
 	provider = strings.ToLower(provider)
@@ -141,6 +218,9 @@ func GetProviderAPIKey(provider string) string {
 	case "anthropic":
 		return cfg.AnthropicAPIKey
 	}
+	if cp, ok := cfg.CustomProviders[provider]; ok {
+		return os.ExpandEnv(cp.APIKey)
+	}
 
 	return ""
 }
@@ -179,27 +259,92 @@ func GetProviderModel(provider string) string {
 		}
 	}
 
+	if cp, ok := cfg.CustomProviders[provider]; ok {
+		if cp.Model != "" {
+			return cp.Model
+		}
+		if cp.Deployment != "" {
+			return cp.Deployment
+		}
+	}
+	if p, ok := cfg.Plugins[provider]; ok && p.Model != "" {
+		return p.Model
+	}
+
 	// Fall back to generic model setting
 	if cfg.Model != "" {
 		return cfg.Model
 	}
+	_, custom := cfg.CustomProviders[provider]
+	_, plugin := cfg.Plugins[provider]
+	if custom || plugin {
+		return "" // Let the server or plugin pick its default
+	}
 
 	return getDefaultModel(provider)
 }
 
+// DefaultModels is the model each built-in provider uses when none is configured
+var DefaultModels = map[string]string{
+	"groq":      "llama-3.3-70b-versatile",
+	"openai":    "gpt-4o-mini",
+	"anthropic": "claude-3-5-sonnet-20241022",
+	"ollama":    "llama3.2",
+}
+
 func getDefaultModel(provider string) string {
-	switch provider {
-	case "groq":
-		return "llama-3.3-70b-versatile"
-	case "openai":
-		return "gpt-4o-mini"
-	case "anthropic":
-		return "claude-3-5-sonnet-20241022"
-	case "ollama":
-		return "llama3.2"
-	default:
-		return "llama-3.3-70b-versatile"
+	if model, ok := DefaultModels[provider]; ok {
+		return model
+	}
+	return DefaultModels["groq"]
+}
+
+// GetCustomProvider returns the custom provider configured under name
+func GetCustomProvider(name string) (CustomProvider, bool) {
+	cfg, err := Load()
+	if err != nil {
+		return CustomProvider{}, false
 	}
+	cp, ok := cfg.CustomProviders[strings.ToLower(name)]
+	return cp, ok
+}
+
+// GetCustomProviderNames returns the names of all custom providers, sorted
+func GetCustomProviderNames() []string {
+	cfg, err := Load()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.CustomProviders))
+	for name := range cfg.CustomProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetPlugin returns the provider plugin configured under name
+func GetPlugin(name string) (Plugin, bool) {
+	cfg, err := Load()
+	if err != nil {
+		return Plugin{}, false
+	}
+	p, ok := cfg.Plugins[strings.ToLower(name)]
+	return p, ok
+}
+
+// GetPluginNames returns the names of all provider plugins, sorted
+func GetPluginNames() []string {
+	cfg, err := Load()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Plugins))
+	for name := range cfg.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // GetOllamaEndpoint returns the Ollama endpoint
@@ -216,6 +361,56 @@ func GetOllamaEndpoint() string {
 	return cfg.OllamaEndpoint
 }
 
+// GetMaxRetries returns the configured retry count, if set
+func GetMaxRetries() (int, bool) {
+	if v := os.Getenv("OPENSKILL_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n, true
+		}
+	}
+
+	cfg, err := Load()
+	if err != nil || cfg.MaxRetries == nil {
+		return 0, false
+	}
+	return *cfg.MaxRetries, true
+}
+
+// GetRetryMaxWait returns the configured retry budget, if set
+func GetRetryMaxWait() (time.Duration, bool) {
+	cfg, err := Load()
+	if err != nil || cfg.RetryMaxWait == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(cfg.RetryMaxWait)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// GetCacheTTL returns the configured cache lifetime, if set
+func GetCacheTTL() (time.Duration, bool) {
+	cfg, err := Load()
+	if err != nil || cfg.CacheTTL == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(cfg.CacheTTL)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// GetCacheMaxMB returns the configured cache size limit, if set
+func GetCacheMaxMB() (int, bool) {
+	cfg, err := Load()
+	if err != nil || cfg.CacheMaxMB <= 0 {
+		return 0, false
+	}
+	return cfg.CacheMaxMB, true
+}
+
 // Legacy functions for backwards compatibility
 func GetAPIKey() string {
 	return GetProviderAPIKey(GetProvider())
@@ -25,6 +25,15 @@ type Skill struct {
 
 	// Chaining/Workflows
 	Chain      []string          `yaml:"chain,omitempty" json:"chain,omitempty"`           // Skills to run in sequence
+
+	// Few-shot examples
+	Examples   []Example         `yaml:"examples,omitempty" json:"examples,omitempty"`     // Sample exchanges sent before the input
+}
+
+// Example is a sample input and the response the skill should give
+type Example struct {
+	Input  string `yaml:"input" json:"input"`
+	Output string `yaml:"output" json:"output"`
 }
 
 // ContextConfig defines how a skill gathers context
@@ -67,3 +76,14 @@ type Workspace struct {
 	Groups      []string `yaml:"groups,omitempty" json:"groups,omitempty"`   // Groups enabled in this workspace
 	Overrides   map[string]map[string]string `yaml:"overrides,omitempty" json:"overrides,omitempty"` // Variable overrides per skill
 }
+
+// TagRegistry documents the tags used across skills
+type TagRegistry struct {
+	Tags map[string]*TagDefinition `yaml:"tags,omitempty" json:"tags,omitempty"` // Keyed by canonical tag
+}
+
+// TagDefinition describes a canonical tag and the aliases that resolve to it
+type TagDefinition struct {
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Aliases     []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+}
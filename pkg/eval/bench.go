@@ -0,0 +1,145 @@
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"openskill/pkg/usage"
+)
+
+// Target is one provider and model in a benchmark
+type Target struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Model    string `json:"model" yaml:"model"`
+}
+
+// ParseTarget parses "provider" or "provider:model". Only the first colon
+// separates them, so Ollama tags like "ollama:llama3:8b" work.
+func ParseTarget(s string) (Target, error) {
+	provider, model, _ := strings.Cut(strings.TrimSpace(s), ":")
+	if provider == "" {
+		return Target{}, fmt.Errorf("invalid target %q (expected provider or provider:model)", s)
+	}
+	return Target{Provider: strings.ToLower(provider), Model: model}, nil
+}
+
+func (t Target) String() string {
+	if t.Model == "" {
+		return t.Provider
+	}
+	return t.Provider + ":" + t.Model
+}
+
+// BenchResult summarizes a suite run against one target
+type BenchResult struct {
+	Target   `yaml:",inline"`
+	Cases    int     `json:"cases" yaml:"cases"`
+	Passed   int     `json:"passed" yaml:"passed"`
+	Errors   int     `json:"errors" yaml:"errors"` // Cases that couldn't run
+	PassRate float64 `json:"pass_rate" yaml:"pass_rate"`
+
+	// Compliance is the share of applicable rule verdicts that were
+	// followed; nil without rule judging or when no rule applied
+	Compliance *float64   `json:"compliance,omitempty" yaml:"compliance,omitempty"`
+	Scorecard  *Scorecard `json:"scorecard,omitempty" yaml:"scorecard,omitempty"`
+
+	MedianMS         int64   `json:"median_ms" yaml:"median_ms"`
+	P95MS            int64   `json:"p95_ms" yaml:"p95_ms"`
+	PromptTokens     int     `json:"prompt_tokens" yaml:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens" yaml:"completion_tokens"`
+	Cost             float64 `json:"cost_usd" yaml:"cost_usd"`               // Responses only, not judging
+	Priced           bool    `json:"priced" yaml:"priced"`                   // The model's price is known
+	Error            string  `json:"error,omitempty" yaml:"error,omitempty"` // The target couldn't run
+
+	Results []CaseResult `json:"results,omitempty" yaml:"results,omitempty"`
+}
+
+// NewBenchResult summarizes the results of a suite run against a target.
+// rules, when set, are the expanded skill rules the cases were judged on.
+func NewBenchResult(target Target, results []CaseResult, rules []string) BenchResult {
+	b := BenchResult{Target: target, Cases: len(results), Results: results}
+	var card *Scorecard
+	if rules != nil {
+		card = NewScorecard(rules)
+	}
+
+	var durations []int64
+	for _, r := range results {
+		if r.Pass {
+			b.Passed++
+		}
+		if r.Error != "" {
+			b.Errors++
+		}
+		if card != nil && r.Rules != nil {
+			card.Add(r.Rules)
+		}
+		if r.Response != "" {
+			durations = append(durations, r.DurationMS)
+		}
+		b.PromptTokens += r.PromptTokens
+		b.CompletionTokens += r.CompletionTokens
+	}
+	if b.Cases > 0 {
+		b.PassRate = float64(b.Passed) / float64(b.Cases)
+	}
+	if card != nil {
+		b.Scorecard = card
+		if score := card.Compliance(); score >= 0 {
+			b.Compliance = &score
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	b.MedianMS = percentile(durations, 50)
+	b.P95MS = percentile(durations, 95)
+
+	if price, ok := usage.PriceFor(target.Provider, target.Model); ok {
+		b.Priced = true
+		b.Cost = usage.Cost(price, b.PromptTokens, b.CompletionTokens)
+	}
+	return b
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Cheapest returns the index of the lowest-cost result whose pass rate is
+// at least minPassRate, or -1 when none is. Unpriced targets are only
+// picked when no priced one qualifies; ties go to the faster target.
+func Cheapest(results []BenchResult, minPassRate float64) int {
+	best := -1
+	for i, r := range results {
+		if r.Error != "" || r.PassRate < minPassRate {
+			continue
+		}
+		if best == -1 {
+			best = i
+			continue
+		}
+		b := results[best]
+		switch {
+		case r.Priced != b.Priced:
+			if r.Priced {
+				best = i
+			}
+		case r.Cost != b.Cost:
+			if r.Cost < b.Cost {
+				best = i
+			}
+		case r.MedianMS < b.MedianMS:
+			best = i
+		}
+	}
+	return best
+}
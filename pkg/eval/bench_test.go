@@ -0,0 +1,178 @@
+package eval
+
+import (
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Target
+		str     string
+		wantErr bool
+	}{
+		{spec: "groq", want: Target{Provider: "groq"}, str: "groq"},
+		{spec: "OpenAI:gpt-4o-mini", want: Target{Provider: "openai", Model: "gpt-4o-mini"}, str: "openai:gpt-4o-mini"},
+		{spec: "ollama:llama3:8b", want: Target{Provider: "ollama", Model: "llama3:8b"}, str: "ollama:llama3:8b"},
+		{spec: "  anthropic  ", want: Target{Provider: "anthropic"}, str: "anthropic"},
+		{spec: "groq:", want: Target{Provider: "groq"}, str: "groq"},
+		{spec: "", wantErr: true},
+		{spec: ":gpt-4o", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseTarget(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTarget(%q) = %+v, want an error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTarget(%q) error = %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTarget(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	ten := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name   string
+		sorted []int64
+		p      int
+		want   int64
+	}{
+		{"empty", nil, 50, 0},
+		{"single value", []int64{42}, 95, 42},
+		{"median of an even count takes the lower middle", []int64{10, 20, 30, 40}, 50, 20},
+		{"median of an odd count", []int64{10, 20, 30}, 50, 20},
+		{"p95 of ten rounds up to the last", ten, 95, 10},
+		{"p90 of ten lands exactly", ten, 90, 9},
+		{"p91 of ten rounds up", ten, 91, 10},
+		{"p0 is the minimum", ten, 0, 1},
+		{"p100 is the maximum", ten, 100, 10},
+		{"p95 of twenty", []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, 95, 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %d) = %d, want %d", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	priced := func(cost float64, passRate float64, medianMS int64) BenchResult {
+		return BenchResult{Priced: true, Cost: cost, PassRate: passRate, MedianMS: medianMS}
+	}
+	tests := []struct {
+		name    string
+		results []BenchResult
+		minPass float64
+		want    int
+	}{
+		{
+			name: "no results",
+			want: -1,
+		},
+		{
+			name:    "lowest cost wins",
+			results: []BenchResult{priced(0.02, 1, 100), priced(0.01, 1, 900), priced(0.03, 1, 50)},
+			minPass: 1,
+			want:    1,
+		},
+		{
+			name:    "targets below the bar are skipped",
+			results: []BenchResult{priced(0.01, 0.8, 100), priced(0.05, 0.9, 100)},
+			minPass: 0.9,
+			want:    1,
+		},
+		{
+			name:    "none reaches the bar",
+			results: []BenchResult{priced(0.01, 0.5, 100)},
+			minPass: 0.9,
+			want:    -1,
+		},
+		{
+			name:    "targets that failed to run are skipped",
+			results: []BenchResult{{Error: "provider not configured", PassRate: 1}, priced(0.05, 1, 100)},
+			minPass: 0,
+			want:    1,
+		},
+		{
+			name:    "priced beats unpriced even when dearer",
+			results: []BenchResult{{PassRate: 1, MedianMS: 10}, priced(0.50, 1, 1000)},
+			minPass: 1,
+			want:    1,
+		},
+		{
+			name:    "unpriced is picked when nothing priced qualifies",
+			results: []BenchResult{priced(0.01, 0.2, 100), {PassRate: 1, MedianMS: 300}},
+			minPass: 1,
+			want:    1,
+		},
+		{
+			name:    "equal cost goes to the faster target",
+			results: []BenchResult{priced(0.01, 1, 300), priced(0.01, 1, 200)},
+			minPass: 1,
+			want:    1,
+		},
+		{
+			name:    "equal cost and speed keeps the first",
+			results: []BenchResult{priced(0.01, 1, 200), priced(0.01, 1, 200)},
+			minPass: 1,
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cheapest(tt.results, tt.minPass); got != tt.want {
+				t.Errorf("Cheapest() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBenchResult(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // No configured prices
+	target := Target{Provider: "nosuchprovider", Model: "m"}
+	results := []CaseResult{
+		{Name: "a", Pass: true, Response: "x", DurationMS: 300, PromptTokens: 10, CompletionTokens: 5,
+			Rules: []RuleVerdict{{Rule: 1, Verdict: Followed}}},
+		{Name: "b", Response: "y", DurationMS: 100, PromptTokens: 20, CompletionTokens: 7,
+			Rules: []RuleVerdict{{Rule: 1, Verdict: Violated}}},
+		{Name: "c", Error: "timeout", DurationMS: 5000},
+	}
+	b := NewBenchResult(target, results, []string{"be brief"})
+	if b.Cases != 3 || b.Passed != 1 || b.Errors != 1 {
+		t.Errorf("counts = %d/%d/%d, want 3 cases, 1 passed, 1 error", b.Cases, b.Passed, b.Errors)
+	}
+	if b.PassRate != 1.0/3 {
+		t.Errorf("PassRate = %v, want 1/3", b.PassRate)
+	}
+	// Cases without a response don't count toward latency
+	if b.MedianMS != 100 || b.P95MS != 300 {
+		t.Errorf("latency = %d/%d, want 100/300", b.MedianMS, b.P95MS)
+	}
+	if b.PromptTokens != 30 || b.CompletionTokens != 12 {
+		t.Errorf("tokens = %d/%d, want 30/12", b.PromptTokens, b.CompletionTokens)
+	}
+	if b.Compliance == nil || *b.Compliance != 0.5 {
+		t.Errorf("Compliance = %v, want 0.5", b.Compliance)
+	}
+	if b.Priced || b.Cost != 0 {
+		t.Errorf("an unknown model is priced at %v", b.Cost)
+	}
+
+	if b := NewBenchResult(target, results, nil); b.Scorecard != nil || b.Compliance != nil {
+		t.Errorf("results without rules have a scorecard")
+	}
+}
@@ -0,0 +1,116 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"openskill/pkg/llm"
+)
+
+// CheckResult is the outcome of one expectation
+type CheckResult struct {
+	Check    string `json:"check" yaml:"check"` // e.g. `contains "foo"`
+	Pass     bool   `json:"pass" yaml:"pass"`
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// Check runs the expectations that don't need a model against a response
+func (e *Expect) Check(response string) []CheckResult {
+	var results []CheckResult
+	haystack := response
+	if e.IgnoreCase {
+		haystack = strings.ToLower(response)
+	}
+	needle := func(s string) string {
+		if e.IgnoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+
+	for _, want := range e.Contains {
+		results = append(results, CheckResult{
+			Check:    fmt.Sprintf("contains %q", want),
+			Pass:     strings.Contains(haystack, needle(want)),
+			Expected: want,
+			Actual:   response,
+		})
+	}
+	for _, unwanted := range e.NotContains {
+		r := CheckResult{Check: fmt.Sprintf("not_contains %q", unwanted), Pass: !strings.Contains(haystack, needle(unwanted))}
+		if !r.Pass {
+			r.Expected = "(no " + unwanted + ")"
+			r.Actual = linesContaining(response, unwanted, e.IgnoreCase)
+		}
+		results = append(results, r)
+	}
+	for _, re := range e.regexes {
+		results = append(results, CheckResult{
+			Check:    fmt.Sprintf("regex /%s/", re),
+			Pass:     re.MatchString(response),
+			Expected: "/" + re.String() + "/",
+			Actual:   response,
+		})
+	}
+	if e.schema != nil {
+		results = append(results, checkSchema(e.schema, response))
+	}
+	if e.MaxLength > 0 {
+		n := len([]rune(response))
+		results = append(results, CheckResult{
+			Check:    fmt.Sprintf("max_length %d", e.MaxLength),
+			Pass:     n <= e.MaxLength,
+			Expected: fmt.Sprintf("at most %d characters", e.MaxLength),
+			Actual:   fmt.Sprintf("%d characters", n),
+		})
+	}
+
+	// Passing checks need no diff
+	for i := range results {
+		if results[i].Pass {
+			results[i].Expected = ""
+			results[i].Actual = ""
+		}
+	}
+	return results
+}
+
+func checkSchema(schema *llm.Schema, response string) CheckResult {
+	r := CheckResult{Check: "json_schema"}
+	// The reply may be any JSON value, such as an array; only dig an
+	// object out of surrounding prose when it doesn't parse as it is
+	var value interface{}
+	err := json.Unmarshal([]byte(llm.StripFence(response)), &value)
+	if err != nil {
+		err = json.Unmarshal([]byte(llm.ExtractJSON(response)), &value)
+	}
+	if err != nil {
+		r.Expected = "valid JSON"
+		r.Actual = fmt.Sprintf("%v\n%s", err, response)
+		return r
+	}
+	if err := schema.Validate(value); err != nil {
+		r.Expected = "JSON matching the schema"
+		r.Actual = err.Error()
+		return r
+	}
+	r.Pass = true
+	return r
+}
+
+// linesContaining returns the lines of text that contain s
+func linesContaining(text, s string, ignoreCase bool) string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		hay, needle := line, s
+		if ignoreCase {
+			hay, needle = strings.ToLower(line), strings.ToLower(s)
+		}
+		if strings.Contains(hay, needle) {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,176 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpectCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		expect   string // YAML for the case's expect block
+		response string
+		want     []CheckResult
+	}{
+		{
+			name:     "nothing to check",
+			expect:   "{}",
+			response: "anything",
+			want:     nil,
+		},
+		{
+			name:     "contains passes without a diff",
+			expect:   "contains: [world]",
+			response: "hello world",
+			want:     []CheckResult{{Check: `contains "world"`, Pass: true}},
+		},
+		{
+			name:     "contains is case-sensitive by default",
+			expect:   "contains: [World]",
+			response: "hello world",
+			want:     []CheckResult{{Check: `contains "World"`, Expected: "World", Actual: "hello world"}},
+		},
+		{
+			name:     "contains with ignore_case",
+			expect:   "contains: [World]\nignore_case: true",
+			response: "HELLO WORLD",
+			want:     []CheckResult{{Check: `contains "World"`, Pass: true}},
+		},
+		{
+			name:     "contains failure shows the original response, not the lowered one",
+			expect:   "contains: [Bye]\nignore_case: true",
+			response: "Hello World",
+			want:     []CheckResult{{Check: `contains "Bye"`, Expected: "Bye", Actual: "Hello World"}},
+		},
+		{
+			name:     "not_contains failure shows the offending lines",
+			expect:   "not_contains: [TODO]",
+			response: "line one\nTODO: fix\nline three\nanother TODO",
+			want: []CheckResult{{
+				Check:    `not_contains "TODO"`,
+				Expected: "(no TODO)",
+				Actual:   "TODO: fix\nanother TODO",
+			}},
+		},
+		{
+			name:     "not_contains with ignore_case matches lines in any case",
+			expect:   "not_contains: [todo]\nignore_case: true",
+			response: "done\nTodo: later",
+			want: []CheckResult{{
+				Check:    `not_contains "todo"`,
+				Expected: "(no todo)",
+				Actual:   "Todo: later",
+			}},
+		},
+		{
+			name:     "not_contains is case-sensitive by default",
+			expect:   "not_contains: [todo]",
+			response: "TODO",
+			want:     []CheckResult{{Check: `not_contains "todo"`, Pass: true}},
+		},
+		{
+			name:     "regex ignores ignore_case",
+			expect:   "regex: ['^[a-z]+$']\nignore_case: true",
+			response: "ABC",
+			want:     []CheckResult{{Check: "regex /^[a-z]+$/", Expected: "/^[a-z]+$/", Actual: "ABC"}},
+		},
+		{
+			name:     "max_length counts characters, not bytes",
+			expect:   "max_length: 3",
+			response: "héé",
+			want:     []CheckResult{{Check: "max_length 3", Pass: true}},
+		},
+		{
+			name:     "max_length exceeded",
+			expect:   "max_length: 3",
+			response: "four",
+			want: []CheckResult{{
+				Check:    "max_length 3",
+				Expected: "at most 3 characters",
+				Actual:   "4 characters",
+			}},
+		},
+		{
+			name:     "json_schema accepts JSON in a code fence",
+			expect:   "json_schema:\n  type: object\n  required: [name]",
+			response: "```json\n{\"name\": \"x\"}\n```",
+			want:     []CheckResult{{Check: "json_schema", Pass: true}},
+		},
+		{
+			name:     "json_schema reports a missing field",
+			expect:   "json_schema:\n  type: object\n  required: [name]",
+			response: `{"other": 1}`,
+			want: []CheckResult{{
+				Check:    "json_schema",
+				Expected: "JSON matching the schema",
+				Actual:   `$: missing required field "name"`,
+			}},
+		},
+		{
+			name:     "json_schema accepts a top-level array",
+			expect:   "json_schema:\n  type: array\n  items:\n    type: object\n    required: [a]",
+			response: `[{"a":1},{"a":2}]`,
+			want:     []CheckResult{{Check: "json_schema", Pass: true}},
+		},
+		{
+			name:     "json_schema reports a bad array item",
+			expect:   "json_schema:\n  type: array\n  items:\n    type: object\n    required: [a]",
+			response: "```json\n[{\"a\":1},{\"b\":2}]\n```",
+			want: []CheckResult{{
+				Check:    "json_schema",
+				Expected: "JSON matching the schema",
+				Actual:   `$[1]: missing required field "a"`,
+			}},
+		},
+		{
+			name:     "json_schema finds an object inside prose",
+			expect:   "json_schema:\n  type: object\n  required: [name]",
+			response: `Here you go: {"name": "x"} Enjoy!`,
+			want:     []CheckResult{{Check: "json_schema", Pass: true}},
+		},
+		{
+			name:     "checks run in a fixed order",
+			expect:   "max_length: 100\nregex: [b]\nnot_contains: [z]\ncontains: [a]",
+			response: "ab",
+			want: []CheckResult{
+				{Check: `contains "a"`, Pass: true},
+				{Check: `not_contains "z"`, Pass: true},
+				{Check: "regex /b/", Pass: true},
+				{Check: "max_length 100", Pass: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite, err := ParseSuite([]byte("cases:\n  - input: x\n    expect:\n" + indent(tt.expect, "      ")))
+			if err != nil {
+				t.Fatalf("ParseSuite() error = %v", err)
+			}
+			got := suite.Cases[0].Expect.Check(tt.response)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Check()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpectCheckInvalidJSON(t *testing.T) {
+	suite, err := ParseSuite([]byte("cases:\n  - input: x\n    expect:\n      json_schema:\n        type: object\n"))
+	if err != nil {
+		t.Fatalf("ParseSuite() error = %v", err)
+	}
+	got := suite.Cases[0].Expect.Check("not json")
+	if len(got) != 1 || got[0].Pass || got[0].Expected != "valid JSON" {
+		t.Fatalf("Check() = %+v, want a failed valid JSON check", got)
+	}
+}
+
+// indent prefixes every line of s
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix) + "\n"
+}
@@ -0,0 +1,313 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"openskill/pkg/core"
+	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
+	"openskill/pkg/render"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Winners of a pairwise comparison
+const (
+	WinV1 = "v1"
+	WinV2 = "v2"
+	Tie   = "tie"
+)
+
+// Z95 is the normal quantile for a 95% confidence interval
+const Z95 = 1.96
+
+// Prompt is one input both versions of a skill answer
+type Prompt struct {
+	Name    string            `yaml:"name" json:"name"`
+	Input   string            `yaml:"input" json:"input"`
+	Context string            `yaml:"context,omitempty" json:"context,omitempty"`
+	Vars    map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+}
+
+// UnmarshalYAML accepts a plain string as a prompt's input
+func (p *Prompt) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&p.Input)
+	}
+	type plain Prompt
+	return node.Decode((*plain)(p))
+}
+
+// LoadPrompts reads a prompts file: a list of prompts, either at the top
+// level or under "prompts". Each is a string or a mapping with input and
+// optionally name, context and vars.
+func LoadPrompts(path string) ([]Prompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	list, err := ParsePrompts(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return list, nil
+}
+
+// ParsePrompts decodes and checks a prompts file
+func ParsePrompts(data []byte) ([]Prompt, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	var list []Prompt
+	if len(doc.Content) > 0 {
+		root := doc.Content[0]
+		var err error
+		if root.Kind == yaml.SequenceNode {
+			err = root.Decode(&list)
+		} else {
+			var file struct {
+				Prompts []Prompt `yaml:"prompts"`
+			}
+			err = root.Decode(&file)
+			list = file.Prompts
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("no prompts defined")
+	}
+	for i := range list {
+		p := &list[i]
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("prompt-%d", i+1)
+		}
+		if strings.TrimSpace(p.Input) == "" {
+			return nil, fmt.Errorf("prompt '%s': input is empty", p.Name)
+		}
+	}
+	return list, nil
+}
+
+// SuitePrompts returns the inputs of a suite's cases as prompts
+func SuitePrompts(suite *Suite) []Prompt {
+	list := make([]Prompt, len(suite.Cases))
+	for i, c := range suite.Cases {
+		list[i] = Prompt{Name: c.Name, Input: c.Input, Context: c.Context, Vars: c.Vars}
+	}
+	return list
+}
+
+// Variant is one version of a skill in a comparison
+type Variant struct {
+	Skill   *core.Skill    // Resolved skill
+	Context *render.Bundle // Gathered skill context, or nil
+}
+
+// Comparer runs two versions of a skill over the same prompts and has a
+// judge pick the better response to each
+type Comparer struct {
+	V1, V2   Variant
+	Vars     map[string]string // Variables for every prompt; a prompt's own vars win
+	Provider llm.Provider      // Generates the responses
+	Judge    llm.Provider      // Picks the better response; nil uses Provider
+	Parallel int               // Prompts run at once; 0 means DefaultParallel
+
+	// Seed decides which version the judge sees first for each prompt, so
+	// position bias can't favor either. 0 picks a random seed.
+	Seed int64
+}
+
+// PairResult is the outcome of one prompt
+type PairResult struct {
+	Name       string `json:"name" yaml:"name"`
+	Winner     string `json:"winner,omitempty" yaml:"winner,omitempty"` // v1, v2 or tie
+	Reason     string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	V2First    bool   `json:"v2_first" yaml:"v2_first"` // v2 was shown to the judge as response A
+	ResponseV1 string `json:"response_v1" yaml:"response_v1"`
+	ResponseV2 string `json:"response_v2" yaml:"response_v2"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"` // The prompt couldn't be compared
+}
+
+// Compare runs every prompt, in parallel, returning results in order
+func (c *Comparer) Compare(ctx context.Context, list []Prompt) []PairResult {
+	parallel := c.Parallel
+	if parallel <= 0 {
+		parallel = DefaultParallel
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(c.Seed))
+	v2First := make([]bool, len(list))
+	for i := range v2First {
+		v2First[i] = rng.Intn(2) == 1
+	}
+
+	results := make([]PairResult, len(list))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range list {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = c.comparePrompt(ctx, &list[i], v2First[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func (c *Comparer) comparePrompt(ctx context.Context, p *Prompt, v2First bool) PairResult {
+	result := PairResult{Name: p.Name, V2First: v2First}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	var err error
+	if result.ResponseV1, err = c.respond(ctx, c.V1, p); err != nil {
+		result.Error = "v1: " + err.Error()
+		return result
+	}
+	if result.ResponseV2, err = c.respond(ctx, c.V2, p); err != nil {
+		result.Error = "v2: " + err.Error()
+		return result
+	}
+
+	judge := c.Judge
+	if judge == nil {
+		judge = c.Provider
+	}
+	a, b := result.ResponseV1, result.ResponseV2
+	if v2First {
+		a, b = b, a
+	}
+	winner, reason, err := JudgePair(ctx, judge, c.V2.Skill.Name, p.Input, a, b)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Reason = reason
+	switch {
+	case winner == "A" && !v2First, winner == "B" && v2First:
+		result.Winner = WinV1
+	case winner == "A" || winner == "B":
+		result.Winner = WinV2
+	default:
+		result.Winner = Tie
+	}
+	return result
+}
+
+func (c *Comparer) respond(ctx context.Context, v Variant, p *Prompt) (string, error) {
+	req, err := render.Compile(render.Input{
+		Skill:   v.Skill,
+		Context: withContext(v.Context, p.Name, p.Context),
+		Vars:    mergeVars(c.Vars, p.Vars),
+		Prompt:  p.Input,
+	})
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.Provider.Chat(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// pairwiseVerdict is the judge's reply to the pairwise prompt
+type pairwiseVerdict struct {
+	Winner string `json:"winner" enum:"A,B,tie"`
+	Reason string `json:"reason" desc:"One or two sentences on why"`
+}
+
+// JudgePair asks a model which of two responses to a prompt is better,
+// returning "A", "B" or "tie" and the judge's reason
+func JudgePair(ctx context.Context, judge llm.Provider, skill, input, a, b string) (string, string, error) {
+	prompt, err := prompts.Render(prompts.Pairwise, prompts.PairwiseData{
+		Skill:     skill,
+		Input:     input,
+		ResponseA: a,
+		ResponseB: b,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	var reply pairwiseVerdict
+	req := llm.PromptRequest(prompt)
+	req.Temperature = llm.Temperature(0)
+	if err := llm.GenerateJSON(ctx, judge, req, "pairwise_verdict", &reply); err != nil {
+		return "", "", fmt.Errorf("judge failed: %w", err)
+	}
+	winner := strings.TrimSpace(reply.Winner)
+	switch strings.ToLower(winner) {
+	case "a", "b":
+		winner = strings.ToUpper(winner)
+	default:
+		winner = Tie
+	}
+	return winner, reply.Reason, nil
+}
+
+// WinRate summarizes a comparison from v2's side
+type WinRate struct {
+	Wins   int `json:"wins" yaml:"wins"`     // v2 judged better
+	Losses int `json:"losses" yaml:"losses"` // v1 judged better
+	Ties   int `json:"ties" yaml:"ties"`
+	Errors int `json:"errors" yaml:"errors"` // Prompts left out
+
+	Rate float64 `json:"rate" yaml:"rate"` // (wins + ties/2) / compared
+	Low  float64 `json:"low" yaml:"low"`   // 95% Wilson interval
+	High float64 `json:"high" yaml:"high"`
+}
+
+// Summarize counts the outcomes of a comparison. Ties count as half a win.
+func Summarize(results []PairResult) WinRate {
+	var w WinRate
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			w.Errors++
+		case r.Winner == WinV2:
+			w.Wins++
+		case r.Winner == WinV1:
+			w.Losses++
+		default:
+			w.Ties++
+		}
+	}
+	n := w.Wins + w.Losses + w.Ties
+	if n > 0 {
+		score := float64(w.Wins) + float64(w.Ties)/2
+		w.Rate = score / float64(n)
+		w.Low, w.High = Wilson(score, n, Z95)
+	}
+	return w
+}
+
+// Wilson returns the Wilson score interval for successes out of n trials
+func Wilson(successes float64, n int, z float64) (float64, float64) {
+	if n == 0 {
+		return 0, 1
+	}
+	total := float64(n)
+	p := successes / total
+	z2 := z * z
+	center := (p + z2/(2*total)) / (1 + z2/total)
+	margin := z / (1 + z2/total) * math.Sqrt(p*(1-p)/total+z2/(4*total*total))
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
@@ -0,0 +1,173 @@
+package eval
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePrompts(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []Prompt
+		wantErr string
+	}{
+		{
+			name: "top-level list of strings",
+			yaml: "- first\n- second\n",
+			want: []Prompt{{Name: "prompt-1", Input: "first"}, {Name: "prompt-2", Input: "second"}},
+		},
+		{
+			name: "prompts key with strings and mappings",
+			yaml: "prompts:\n  - plain\n  - name: custom\n    input: mapped\n    context: extra\n    vars:\n      lang: go\n",
+			want: []Prompt{
+				{Name: "prompt-1", Input: "plain"},
+				{Name: "custom", Input: "mapped", Context: "extra", Vars: map[string]string{"lang": "go"}},
+			},
+		},
+		{
+			name:    "empty file",
+			yaml:    "",
+			wantErr: "no prompts defined",
+		},
+		{
+			name:    "empty list",
+			yaml:    "prompts: []\n",
+			wantErr: "no prompts defined",
+		},
+		{
+			name:    "blank input",
+			yaml:    "- ok\n- name: blank\n  input: \" \"\n",
+			wantErr: "prompt 'blank': input is empty",
+		},
+		{
+			name:    "invalid YAML",
+			yaml:    "- [\n",
+			wantErr: "invalid YAML",
+		},
+		{
+			name:    "wrong shape",
+			yaml:    "prompts: 3\n",
+			wantErr: "invalid YAML",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrompts([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParsePrompts() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePrompts() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePrompts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		winners []string // "error" marks a failed prompt
+		want    WinRate
+	}{
+		{
+			name: "no results",
+			want: WinRate{},
+		},
+		{
+			name:    "only errors",
+			winners: []string{"error", "error"},
+			want:    WinRate{Errors: 2},
+		},
+		{
+			name:    "ties count as half a win",
+			winners: []string{WinV2, Tie, Tie, WinV1},
+			want:    WinRate{Wins: 1, Losses: 1, Ties: 2, Rate: 0.5},
+		},
+		{
+			name:    "errors are left out of the rate",
+			winners: []string{WinV2, WinV2, WinV2, "error"},
+			want:    WinRate{Wins: 3, Errors: 1, Rate: 1},
+		},
+		{
+			name:    "an unknown winner is a tie",
+			winners: []string{WinV2, ""},
+			want:    WinRate{Wins: 1, Ties: 1, Rate: 0.75},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []PairResult
+			for _, w := range tt.winners {
+				if w == "error" {
+					results = append(results, PairResult{Error: "judge failed"})
+				} else {
+					results = append(results, PairResult{Winner: w})
+				}
+			}
+			got := Summarize(results)
+			if got.Wins != tt.want.Wins || got.Losses != tt.want.Losses || got.Ties != tt.want.Ties || got.Errors != tt.want.Errors {
+				t.Errorf("counts = %+v, want %+v", got, tt.want)
+			}
+			if got.Rate != tt.want.Rate {
+				t.Errorf("Rate = %v, want %v", got.Rate, tt.want.Rate)
+			}
+			n := tt.want.Wins + tt.want.Losses + tt.want.Ties
+			if n == 0 {
+				if got.Low != 0 || got.High != 0 {
+					t.Errorf("interval = [%v, %v], want none", got.Low, got.High)
+				}
+				return
+			}
+			low, high := Wilson(float64(tt.want.Wins)+float64(tt.want.Ties)/2, n, Z95)
+			if got.Low != low || got.High != high {
+				t.Errorf("interval = [%v, %v], want [%v, %v]", got.Low, got.High, low, high)
+			}
+		})
+	}
+}
+
+func TestWilson(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes float64
+		n         int
+		low, high float64
+	}{
+		{"no trials", 0, 0, 0, 1},
+		{"all failures", 0, 10, 0, 0.2775},
+		{"all successes", 10, 10, 0.7225, 1},
+		{"half", 5, 10, 0.2366, 0.7634},
+		{"half a success from a tie", 0.5, 1, 0.0546, 0.9454},
+		{"large sample", 80, 100, 0.7112, 0.8666},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high := Wilson(tt.successes, tt.n, Z95)
+			if math.Abs(low-tt.low) > 1e-4 || math.Abs(high-tt.high) > 1e-4 {
+				t.Errorf("Wilson(%v, %d) = [%.4f, %.4f], want [%.4f, %.4f]", tt.successes, tt.n, low, high, tt.low, tt.high)
+			}
+			if low < 0 || high > 1 || low > high {
+				t.Errorf("Wilson(%v, %d) = [%v, %v] is not a valid interval", tt.successes, tt.n, low, high)
+			}
+		})
+	}
+}
+
+func TestSuitePrompts(t *testing.T) {
+	suite := &Suite{Cases: []Case{
+		{Name: "a", Input: "in", Context: "ctx", Vars: map[string]string{"k": "v"}, Expect: Expect{Contains: []string{"x"}}},
+	}}
+	want := []Prompt{{Name: "a", Input: "in", Context: "ctx", Vars: map[string]string{"k": "v"}}}
+	if got := SuitePrompts(suite); !reflect.DeepEqual(got, want) {
+		t.Errorf("SuitePrompts() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+
+	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
+)
+
+// Rule verdicts given by the judge
+const (
+	Followed      = "followed"
+	Violated      = "violated"
+	NotApplicable = "not_applicable"
+)
+
+// RuleVerdict is the judge's finding for one rule and one response
+type RuleVerdict struct {
+	Rule     int    `json:"rule" yaml:"rule"` // 1-based
+	Text     string `json:"text" yaml:"text"`
+	Verdict  string `json:"verdict" yaml:"verdict"`
+	Evidence string `json:"evidence" yaml:"evidence"`
+}
+
+// complianceReply is the judge's reply to the compliance prompt
+type complianceReply struct {
+	Verdicts []struct {
+		Rule     int    `json:"rule" desc:"Number of the rule"`
+		Verdict  string `json:"verdict" enum:"followed,violated,not_applicable"`
+		Evidence string `json:"evidence" desc:"Short quote from the response, or what it lacks"`
+	} `json:"verdicts"`
+}
+
+// JudgeRules asks a model whether a response follows each rule. Rules the
+// judge skips are reported as not applicable.
+func JudgeRules(ctx context.Context, judge llm.Provider, skill, input, response string, rules []string) ([]RuleVerdict, error) {
+	prompt, err := prompts.Render(prompts.Compliance, prompts.ComplianceData{
+		Skill:    skill,
+		Input:    input,
+		Response: response,
+		Rules:    rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reply complianceReply
+	req := llm.PromptRequest(prompt)
+	req.Temperature = llm.Temperature(0)
+	if err := llm.GenerateJSON(ctx, judge, req, "rule_verdicts", &reply); err != nil {
+		return nil, fmt.Errorf("judge failed: %w", err)
+	}
+
+	verdicts := make([]RuleVerdict, len(rules))
+	for i, rule := range rules {
+		verdicts[i] = RuleVerdict{Rule: i + 1, Text: rule, Verdict: NotApplicable, Evidence: "(no verdict from the judge)"}
+	}
+	for _, v := range reply.Verdicts {
+		if v.Rule < 1 || v.Rule > len(rules) {
+			continue
+		}
+		verdicts[v.Rule-1].Verdict = v.Verdict
+		verdicts[v.Rule-1].Evidence = v.Evidence
+	}
+	return verdicts, nil
+}
+
+// Scorecard aggregates rule verdicts over many responses
+type Scorecard struct {
+	Rules     []RuleScore `json:"rules" yaml:"rules"`
+	Responses int         `json:"responses" yaml:"responses"`
+}
+
+// RuleScore counts the verdicts for one rule
+type RuleScore struct {
+	Rule          int      `json:"rule" yaml:"rule"`
+	Text          string   `json:"text" yaml:"text"`
+	Followed      int      `json:"followed" yaml:"followed"`
+	Violated      int      `json:"violated" yaml:"violated"`
+	NotApplicable int      `json:"not_applicable" yaml:"not_applicable"`
+	Violations    []string `json:"violations,omitempty" yaml:"violations,omitempty"` // Evidence for each violation
+}
+
+// NewScorecard starts a scorecard for a skill's rules
+func NewScorecard(rules []string) *Scorecard {
+	s := &Scorecard{Rules: make([]RuleScore, len(rules))}
+	for i, rule := range rules {
+		s.Rules[i] = RuleScore{Rule: i + 1, Text: rule}
+	}
+	return s
+}
+
+// Add records the verdicts for one response
+func (s *Scorecard) Add(verdicts []RuleVerdict) {
+	s.Responses++
+	for _, v := range verdicts {
+		if v.Rule < 1 || v.Rule > len(s.Rules) {
+			continue
+		}
+		r := &s.Rules[v.Rule-1]
+		switch v.Verdict {
+		case Followed:
+			r.Followed++
+		case Violated:
+			r.Violated++
+			r.Violations = append(r.Violations, v.Evidence)
+		default:
+			r.NotApplicable++
+		}
+	}
+}
+
+// Compliance is the share of applicable responses that followed the
+// rule, or -1 when the rule never applied
+func (r RuleScore) Compliance() float64 {
+	if r.Followed+r.Violated == 0 {
+		return -1
+	}
+	return float64(r.Followed) / float64(r.Followed+r.Violated)
+}
+
+// Compliance is the share of applicable verdicts across all rules that
+// were followed, or -1 when no rule applied
+func (s *Scorecard) Compliance() float64 {
+	total := RuleScore{}
+	for _, r := range s.Rules {
+		total.Followed += r.Followed
+		total.Violated += r.Violated
+	}
+	return total.Compliance()
+}
@@ -0,0 +1,86 @@
+package eval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScorecard(t *testing.T) {
+	rules := []string{"be brief", "cite sources", "never guess"}
+	tests := []struct {
+		name       string
+		responses  [][]RuleVerdict
+		want       []RuleScore
+		compliance float64
+	}{
+		{
+			name: "no responses",
+			want: []RuleScore{
+				{Rule: 1, Text: "be brief"},
+				{Rule: 2, Text: "cite sources"},
+				{Rule: 3, Text: "never guess"},
+			},
+			compliance: -1,
+		},
+		{
+			name: "verdicts are counted per rule",
+			responses: [][]RuleVerdict{
+				{{Rule: 1, Verdict: Followed}, {Rule: 2, Verdict: Violated, Evidence: "no links"}, {Rule: 3, Verdict: NotApplicable}},
+				{{Rule: 1, Verdict: Followed}, {Rule: 2, Verdict: Followed}, {Rule: 3, Verdict: NotApplicable}},
+			},
+			want: []RuleScore{
+				{Rule: 1, Text: "be brief", Followed: 2},
+				{Rule: 2, Text: "cite sources", Followed: 1, Violated: 1, Violations: []string{"no links"}},
+				{Rule: 3, Text: "never guess", NotApplicable: 2},
+			},
+			compliance: 0.75,
+		},
+		{
+			name: "unknown verdicts count as not applicable and out-of-range rules are dropped",
+			responses: [][]RuleVerdict{
+				{{Rule: 0, Verdict: Violated}, {Rule: 4, Verdict: Violated}, {Rule: 1, Verdict: "maybe"}},
+			},
+			want: []RuleScore{
+				{Rule: 1, Text: "be brief", NotApplicable: 1},
+				{Rule: 2, Text: "cite sources"},
+				{Rule: 3, Text: "never guess"},
+			},
+			compliance: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := NewScorecard(rules)
+			for _, verdicts := range tt.responses {
+				card.Add(verdicts)
+			}
+			if card.Responses != len(tt.responses) {
+				t.Errorf("Responses = %d, want %d", card.Responses, len(tt.responses))
+			}
+			if !reflect.DeepEqual(card.Rules, tt.want) {
+				t.Errorf("Rules = %+v, want %+v", card.Rules, tt.want)
+			}
+			if got := card.Compliance(); got != tt.compliance {
+				t.Errorf("Compliance() = %v, want %v", got, tt.compliance)
+			}
+		})
+	}
+}
+
+func TestRuleScoreCompliance(t *testing.T) {
+	tests := []struct {
+		score RuleScore
+		want  float64
+	}{
+		{RuleScore{}, -1},
+		{RuleScore{NotApplicable: 5}, -1},
+		{RuleScore{Followed: 3}, 1},
+		{RuleScore{Violated: 2, NotApplicable: 4}, 0},
+		{RuleScore{Followed: 1, Violated: 3, NotApplicable: 10}, 0.25},
+	}
+	for _, tt := range tests {
+		if got := tt.score.Compliance(); got != tt.want {
+			t.Errorf("%+v.Compliance() = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
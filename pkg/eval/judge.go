@@ -0,0 +1,54 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+
+	"openskill/pkg/llm"
+	"openskill/pkg/prompts"
+)
+
+// criteriaVerdicts is the judge's reply to the criteria prompt
+type criteriaVerdicts struct {
+	Verdicts []struct {
+		Criterion int    `json:"criterion" desc:"Number of the criterion"`
+		Met       bool   `json:"met" desc:"Whether the response meets the criterion"`
+		Reason    string `json:"reason" desc:"One sentence, quoting the response where possible"`
+	} `json:"verdicts"`
+}
+
+// JudgeCriteria asks a model whether a response meets each criterion
+func JudgeCriteria(ctx context.Context, judge llm.Provider, input, response string, criteria []string) ([]CheckResult, error) {
+	prompt, err := prompts.Render(prompts.Criteria, prompts.CriteriaData{
+		Input:    input,
+		Response: response,
+		Criteria: criteria,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reply criteriaVerdicts
+	req := llm.PromptRequest(prompt)
+	req.Temperature = llm.Temperature(0)
+	if err := llm.GenerateJSON(ctx, judge, req, "criteria_verdicts", &reply); err != nil {
+		return nil, fmt.Errorf("judge failed: %w", err)
+	}
+
+	results := make([]CheckResult, len(criteria))
+	for i, c := range criteria {
+		results[i] = CheckResult{Check: fmt.Sprintf("criterion %q", c), Expected: c, Actual: "(no verdict from the judge)"}
+	}
+	for _, v := range reply.Verdicts {
+		if v.Criterion < 1 || v.Criterion > len(criteria) {
+			continue
+		}
+		r := &results[v.Criterion-1]
+		r.Pass = v.Met
+		r.Actual = v.Reason
+		if r.Pass {
+			r.Expected, r.Actual = "", ""
+		}
+	}
+	return results, nil
+}
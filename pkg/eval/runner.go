@@ -0,0 +1,153 @@
+package eval
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"openskill/pkg/core"
+	"openskill/pkg/llm"
+	"openskill/pkg/render"
+)
+
+// DefaultParallel is how many cases run at once unless told otherwise
+const DefaultParallel = 4
+
+// Runner runs a suite's cases against a skill
+type Runner struct {
+	Skill    *core.Skill       // Resolved skill under test
+	Context  *render.Bundle    // Gathered skill context, or nil
+	Vars     map[string]string // Variables for every case; a case's own vars win
+	Provider llm.Provider      // Generates the responses
+	Judge    llm.Provider      // Judges criteria and rules; nil uses Provider
+	Parallel int               // Cases run at once; 0 means DefaultParallel
+
+	JudgeRules bool // Also have the judge check each response against the skill's rules
+}
+
+// CaseResult is the outcome of one case
+type CaseResult struct {
+	Name     string        `json:"name" yaml:"name"`
+	Pass     bool          `json:"pass" yaml:"pass"`
+	Response string        `json:"response" yaml:"response"`
+	Checks   []CheckResult `json:"checks" yaml:"checks"`
+	Rules    []RuleVerdict `json:"rules,omitempty" yaml:"rules,omitempty"` // With JudgeRules
+	Error    string        `json:"error,omitempty" yaml:"error,omitempty"` // The case couldn't run
+
+	DurationMS       int64 `json:"duration_ms" yaml:"duration_ms"`
+	PromptTokens     int   `json:"prompt_tokens" yaml:"prompt_tokens"`
+	CompletionTokens int   `json:"completion_tokens" yaml:"completion_tokens"`
+}
+
+// Run runs every case, in parallel, returning results in suite order
+func (r *Runner) Run(ctx context.Context, suite *Suite) []CaseResult {
+	parallel := r.Parallel
+	if parallel <= 0 {
+		parallel = DefaultParallel
+	}
+
+	results := make([]CaseResult, len(suite.Cases))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range suite.Cases {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = r.runCase(ctx, &suite.Cases[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func (r *Runner) runCase(ctx context.Context, c *Case) CaseResult {
+	result := CaseResult{Name: c.Name}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	vars := mergeVars(r.Vars, c.Vars)
+	bundle := withContext(r.Context, c.Name, c.Context)
+	req, err := render.Compile(render.Input{Skill: r.Skill, Context: bundle, Vars: vars, Prompt: c.Input})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	start := time.Now()
+	resp, err := r.Provider.Chat(ctx, req)
+	result.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Response = resp.Content
+	result.PromptTokens = resp.Usage.PromptTokens
+	result.CompletionTokens = resp.Usage.CompletionTokens
+
+	judge := r.Judge
+	if judge == nil {
+		judge = r.Provider
+	}
+	result.Checks = c.Expect.Check(resp.Content)
+	if len(c.Expect.Criteria) > 0 {
+		verdicts, err := JudgeCriteria(ctx, judge, c.Input, resp.Content, c.Expect.Criteria)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Checks = append(result.Checks, verdicts...)
+	}
+	if r.JudgeRules && len(r.Skill.Rules) > 0 {
+		verdicts, err := JudgeRules(ctx, judge, r.Skill.Name, c.Input, resp.Content, ExpandRules(r.Skill, vars))
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Rules = verdicts
+	}
+
+	result.Pass = true
+	for _, check := range result.Checks {
+		if !check.Pass {
+			result.Pass = false
+		}
+	}
+	return result
+}
+
+// mergeVars combines variables, later maps winning
+func mergeVars(maps ...map[string]string) map[string]string {
+	vars := map[string]string{}
+	for _, m := range maps {
+		for k, v := range m {
+			vars[k] = v
+		}
+	}
+	return vars
+}
+
+// withContext returns base plus text as context from source
+func withContext(base *render.Bundle, source, text string) *render.Bundle {
+	bundle := &render.Bundle{}
+	if base != nil {
+		bundle.Items = append(bundle.Items, base.Items...)
+	}
+	if text != "" {
+		bundle.Items = append(bundle.Items, render.Item{Kind: render.KindText, Source: source, Content: text})
+	}
+	return bundle
+}
+
+// ExpandRules returns a skill's rules with its variables filled in
+func ExpandRules(skill *core.Skill, vars map[string]string) []string {
+	merged := render.Variables(skill, vars)
+	rules := make([]string, len(skill.Rules))
+	for i, rule := range skill.Rules {
+		rules[i] = render.Expand(rule, merged)
+	}
+	return rules
+}
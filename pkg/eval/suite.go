@@ -0,0 +1,198 @@
+// Package eval runs declarative test suites against skills
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"openskill/pkg/llm"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SuiteFile is the test suite stored in a skill's directory
+const SuiteFile = "tests.yaml"
+
+// Suite is the set of test cases for one skill
+type Suite struct {
+	Cases []Case `yaml:"cases" json:"cases"`
+
+	Path string `yaml:"-" json:"-"` // File the suite was loaded from
+}
+
+// Case is one prompt and what its response must look like
+type Case struct {
+	Name    string            `yaml:"name" json:"name"`
+	Input   string            `yaml:"input" json:"input"`
+	Context string            `yaml:"context,omitempty" json:"context,omitempty"` // Extra context for this case only
+	Vars    map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`       // Override the skill's variables
+	Expect  Expect            `yaml:"expect" json:"expect"`
+}
+
+// Expect lists the checks a response must pass. Every check that is set
+// must pass.
+type Expect struct {
+	Contains    []string    `yaml:"contains,omitempty" json:"contains,omitempty"`
+	NotContains []string    `yaml:"not_contains,omitempty" json:"not_contains,omitempty"`
+	Regex       []string    `yaml:"regex,omitempty" json:"regex,omitempty"`
+	JSONSchema  interface{} `yaml:"json_schema,omitempty" json:"json_schema,omitempty"` // The response must be JSON matching it
+	MaxLength   int         `yaml:"max_length,omitempty" json:"max_length,omitempty"`   // In characters
+	Criteria    []string    `yaml:"criteria,omitempty" json:"criteria,omitempty"`       // Judged by a model
+
+	IgnoreCase bool `yaml:"ignore_case,omitempty" json:"ignore_case,omitempty"` // For contains and not_contains
+
+	regexes []*regexp.Regexp
+	schema  *llm.Schema
+}
+
+// ErrNoSuite is returned by LoadSuite when a skill has no tests.yaml
+var ErrNoSuite = errors.New("no " + SuiteFile)
+
+// LoadSuite reads and checks the tests.yaml in a skill directory
+func LoadSuite(skillDir string) (*Suite, error) {
+	path := filepath.Join(skillDir, SuiteFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSuite
+	}
+	if err != nil {
+		return nil, err
+	}
+	suite, err := ParseSuite(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	suite.Path = path
+	return suite, nil
+}
+
+// ParseSuite decodes a suite and compiles its regexes and schemas
+func ParseSuite(data []byte) (*Suite, error) {
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(suite.Cases) == 0 {
+		return nil, fmt.Errorf("no cases defined")
+	}
+
+	names := map[string]bool{}
+	for i := range suite.Cases {
+		c := &suite.Cases[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("duplicate case name '%s'", c.Name)
+		}
+		names[c.Name] = true
+		if strings.TrimSpace(c.Input) == "" {
+			return nil, fmt.Errorf("case '%s': input is empty", c.Name)
+		}
+		if err := c.Expect.compile(); err != nil {
+			return nil, fmt.Errorf("case '%s': %w", c.Name, err)
+		}
+	}
+	return &suite, nil
+}
+
+func (e *Expect) compile() error {
+	for _, pattern := range e.Regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		e.regexes = append(e.regexes, re)
+	}
+	if e.JSONSchema != nil {
+		// The schema is written in YAML; round-trip it through JSON to
+		// pick up the JSON Schema field names
+		data, err := json.Marshal(e.JSONSchema)
+		if err != nil {
+			return fmt.Errorf("invalid json_schema: %w", err)
+		}
+		var raw interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("invalid json_schema: %w", err)
+		}
+		if err := supportedSchema(raw, "$"); err != nil {
+			return fmt.Errorf("invalid json_schema: %w", err)
+		}
+		var schema llm.Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("invalid json_schema: %w", err)
+		}
+		e.schema = &schema
+	}
+	if e.MaxLength < 0 {
+		return fmt.Errorf("max_length must not be negative")
+	}
+	return nil
+}
+
+// schemaTypes are the types llm.Schema knows how to validate
+var schemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true,
+	"integer": true, "number": true, "boolean": true,
+}
+
+// supportedSchema rejects schemas using keywords llm.Schema would
+// silently drop, so a suite never passes on a check it didn't make
+func supportedSchema(node interface{}, path string) error {
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("schema at %s must be a mapping", path)
+	}
+	typ, _ := obj["type"].(string)
+	if !schemaTypes[typ] {
+		return fmt.Errorf("schema at %s needs a type of object, array, string, integer, number or boolean", path)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := obj[key]
+		switch key {
+		case "type", "description", "title", "required":
+		case "properties":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("properties at %s must be a mapping", path)
+			}
+			names := make([]string, 0, len(props))
+			for name := range props {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if err := supportedSchema(props[name], path+"."+name); err != nil {
+					return err
+				}
+			}
+		case "items":
+			if err := supportedSchema(value, path+"[]"); err != nil {
+				return err
+			}
+		case "additionalProperties":
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("additionalProperties at %s must be true or false", path)
+			}
+		case "enum":
+			if typ != "string" {
+				return fmt.Errorf("enum at %s is only supported for strings", path)
+			}
+		default:
+			return fmt.Errorf("unsupported keyword %q at %s", key, path)
+		}
+	}
+	return nil
+}
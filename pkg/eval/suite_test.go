@@ -0,0 +1,124 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSuite(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		names   []string // Case names after parsing
+		wantErr string
+	}{
+		{
+			name:  "names default to their position",
+			yaml:  "cases:\n  - input: a\n  - name: second\n    input: b\n  - input: c\n",
+			names: []string{"case-1", "second", "case-3"},
+		},
+		{
+			name:    "no cases",
+			yaml:    "cases: []\n",
+			wantErr: "no cases defined",
+		},
+		{
+			name:    "empty document",
+			yaml:    "",
+			wantErr: "no cases defined",
+		},
+		{
+			name:    "invalid YAML",
+			yaml:    "cases: [\n",
+			wantErr: "invalid YAML",
+		},
+		{
+			name:    "duplicate names",
+			yaml:    "cases:\n  - name: x\n    input: a\n  - name: x\n    input: b\n",
+			wantErr: "duplicate case name 'x'",
+		},
+		{
+			name:    "default name clashes with an explicit one",
+			yaml:    "cases:\n  - name: case-2\n    input: a\n  - input: b\n",
+			wantErr: "duplicate case name 'case-2'",
+		},
+		{
+			name:    "blank input",
+			yaml:    "cases:\n  - name: x\n    input: \"  \\n\"\n",
+			wantErr: "case 'x': input is empty",
+		},
+		{
+			name:    "invalid regex",
+			yaml:    "cases:\n  - input: a\n    expect:\n      regex: ['(']\n",
+			wantErr: "case 'case-1': invalid regex",
+		},
+		{
+			name:    "negative max_length",
+			yaml:    "cases:\n  - input: a\n    expect:\n      max_length: -1\n",
+			wantErr: "max_length must not be negative",
+		},
+		{
+			name:    "unsupported schema keyword",
+			yaml:    "cases:\n  - input: a\n    expect:\n      json_schema:\n        type: object\n        properties:\n          age: {type: integer, minimum: 0}\n",
+			wantErr: `case 'case-1': invalid json_schema: unsupported keyword "minimum" at $.age`,
+		},
+		{
+			name:    "unsupported keyword in array items",
+			yaml:    "cases:\n  - input: a\n    expect:\n      json_schema:\n        type: array\n        items: {$ref: '#/defs/x'}\n",
+			wantErr: "schema at $[] needs a type",
+		},
+		{
+			name:    "schema without a type",
+			yaml:    "cases:\n  - input: a\n    expect:\n      json_schema:\n        required: [name]\n",
+			wantErr: "schema at $ needs a type",
+		},
+		{
+			name:    "enum on a non-string",
+			yaml:    "cases:\n  - input: a\n    expect:\n      json_schema:\n        type: integer\n        enum: [1, 2]\n",
+			wantErr: "enum at $ is only supported for strings",
+		},
+		{
+			name:    "additionalProperties as a schema",
+			yaml:    "cases:\n  - input: a\n    expect:\n      json_schema:\n        type: object\n        additionalProperties: {type: string}\n",
+			wantErr: "additionalProperties at $ must be true or false",
+		},
+		{
+			name:  "schema written in YAML",
+			yaml:  "cases:\n  - input: a\n    expect:\n      json_schema:\n        type: object\n        required: [name]\n",
+			names: []string{"case-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite, err := ParseSuite([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSuite() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSuite() error = %v", err)
+			}
+			if len(suite.Cases) != len(tt.names) {
+				t.Fatalf("got %d cases, want %d", len(suite.Cases), len(tt.names))
+			}
+			for i, want := range tt.names {
+				if got := suite.Cases[i].Name; got != want {
+					t.Errorf("case %d name = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSuiteCompilesSchema(t *testing.T) {
+	suite, err := ParseSuite([]byte("cases:\n  - input: a\n    expect:\n      json_schema:\n        type: object\n        required: [name]\n"))
+	if err != nil {
+		t.Fatalf("ParseSuite() error = %v", err)
+	}
+	schema := suite.Cases[0].Expect.schema
+	if schema == nil || schema.Type != "object" || len(schema.Required) != 1 || schema.Required[0] != "name" {
+		t.Fatalf("schema = %+v, want an object requiring name", schema)
+	}
+}
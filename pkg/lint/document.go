@@ -0,0 +1,252 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"openskill/pkg/core"
+	"openskill/pkg/skills"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a 1-based line/column location in SKILL.md. Line 0 means
+// the finding applies to the whole file.
+type Position struct {
+	Line   int `json:"line" yaml:"line"`
+	Column int `json:"column" yaml:"column"`
+}
+
+// Document is a SKILL.md file parsed with enough position information
+// for rules to point at the offending line
+type Document struct {
+	Path  string
+	Lines []string
+
+	// Skill is the decoded skill, or nil if the frontmatter could not be decoded
+	Skill *core.Skill
+
+	// FrontmatterErr is set when the frontmatter is missing, unclosed or not valid YAML
+	FrontmatterErr    error
+	FrontmatterErrPos Position
+
+	// DecodeErr is set when the YAML is valid but does not match the skill schema
+	DecodeErr error
+
+	// Frontmatter is the YAML mapping node, or nil if it could not be parsed
+	Frontmatter *yaml.Node
+
+	// Rules lists every "- " item in the Rules section, including empty ones
+	Rules []RuleLine
+
+	fmLineOffset int // Added to YAML node lines to get file lines
+	suppressions *suppressions
+}
+
+// RuleLine is one rule item with its location
+type RuleLine struct {
+	Text string
+	Pos  Position // Position of the rule text (after "- ")
+}
+
+var yamlLineRe = regexp.MustCompile(`line (\d+)`)
+
+// ParseDocument parses SKILL.md content. It never fails; problems are
+// recorded on the document for rules to report.
+func ParseDocument(path string, data []byte) *Document {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	doc := &Document{
+		Path:         path,
+		Lines:        strings.Split(content, "\n"),
+		fmLineOffset: 1, // Frontmatter starts after the opening "---" on line 1
+	}
+	doc.suppressions = parseSuppressions(doc.Lines)
+
+	if !strings.HasPrefix(content, "---\n") {
+		doc.FrontmatterErr = fmt.Errorf("missing frontmatter: SKILL.md must start with '---'")
+		doc.FrontmatterErrPos = Position{Line: 1, Column: 1}
+		return doc
+	}
+
+	endIdx := strings.Index(content[4:], "\n---")
+	if endIdx == -1 {
+		doc.FrontmatterErr = fmt.Errorf("unclosed frontmatter: no closing '---'")
+		doc.FrontmatterErrPos = Position{Line: 1, Column: 1}
+		return doc
+	}
+	frontmatter := content[4 : 4+endIdx]
+	bodyStartLine := strings.Count(content[:4+endIdx+4], "\n") + 1
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &root); err != nil {
+		doc.FrontmatterErr = fmt.Errorf("invalid YAML in frontmatter: %v", err)
+		doc.FrontmatterErrPos = Position{Line: 1, Column: 1}
+		if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+			if n, convErr := strconv.Atoi(m[1]); convErr == nil {
+				doc.FrontmatterErrPos = Position{Line: n + doc.fmLineOffset, Column: 1}
+			}
+		}
+		return doc
+	}
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.MappingNode {
+		doc.Frontmatter = root.Content[0]
+	} else if len(root.Content) > 0 {
+		doc.FrontmatterErr = fmt.Errorf("frontmatter must be a YAML mapping of keys to values")
+		doc.FrontmatterErrPos = Position{Line: 2, Column: 1}
+		return doc
+	}
+
+	skill, err := skills.ParseSkill([]byte(content))
+	if err != nil {
+		doc.DecodeErr = err
+	} else {
+		doc.Skill = skill
+	}
+
+	doc.Rules = parseRuleLines(doc.Lines, bodyStartLine)
+	return doc
+}
+
+// parseRuleLines finds "- " items in the "## Rules" section, mirroring
+// how the skill manager reads rules
+func parseRuleLines(lines []string, startLine int) []RuleLine {
+	var rules []RuleLine
+	inRules := false
+	for i := startLine - 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "## Rules") {
+			inRules = true
+			continue
+		}
+		if inRules && strings.HasPrefix(line, "## ") {
+			break
+		}
+		if inRules && (strings.HasPrefix(line, "- ") || strings.TrimSpace(line) == "-") {
+			text := strings.TrimPrefix(strings.TrimPrefix(line, "-"), " ")
+			rules = append(rules, RuleLine{Text: text, Pos: Position{Line: i + 1, Column: 3}})
+		}
+	}
+	return rules
+}
+
+// Key returns the key and value nodes for a top-level frontmatter key
+func (d *Document) Key(name string) (key, value *yaml.Node) {
+	if d.Frontmatter == nil {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(d.Frontmatter.Content); i += 2 {
+		if d.Frontmatter.Content[i].Value == name {
+			return d.Frontmatter.Content[i], d.Frontmatter.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// NodePos converts a frontmatter YAML node position to a file position
+func (d *Document) NodePos(n *yaml.Node) Position {
+	if n == nil {
+		return Position{Line: 1, Column: 1}
+	}
+	return Position{Line: n.Line + d.fmLineOffset, Column: n.Column}
+}
+
+// KeyPos returns the position of a frontmatter key, or the start of the
+// frontmatter if the key is absent
+func (d *Document) KeyPos(name string) Position {
+	key, _ := d.Key(name)
+	return d.NodePos(key)
+}
+
+// ValuePos returns the position of a frontmatter value, or of its key
+// if the value is empty
+func (d *Document) ValuePos(name string) Position {
+	key, value := d.Key(name)
+	if value == nil {
+		return d.NodePos(key)
+	}
+	return d.NodePos(value)
+}
+
+// ============== Suppressions ==============
+
+// Inline directives, in HTML comments in the markdown body or YAML
+// comments in the frontmatter:
+//
+//	<!-- openskill-lint-disable vague-language -->   until a matching enable
+//	<!-- openskill-lint-enable vague-language -->
+//	<!-- openskill-lint-disable-next-line duplicate-rule -->
+//	- Some rule <!-- openskill-lint-disable-line -->
+//
+// Omitting rule IDs applies the directive to every rule.
+var directiveRe = regexp.MustCompile(`openskill-lint-(disable-next-line|disable-line|disable|enable)\b([^>#]*?)\s*(?:-->|$)`)
+
+const allRules = "*"
+
+type suppressions struct {
+	lines map[int]map[string]bool // Line -> suppressed rule IDs
+}
+
+func parseSuppressions(lines []string) *suppressions {
+	s := &suppressions{lines: make(map[int]map[string]bool)}
+	active := make(map[string]bool)
+
+	add := func(line int, ids map[string]bool) {
+		if len(ids) == 0 {
+			return
+		}
+		if s.lines[line] == nil {
+			s.lines[line] = make(map[string]bool)
+		}
+		for id := range ids {
+			s.lines[line][id] = true
+		}
+	}
+
+	for i, text := range lines {
+		lineNo := i + 1
+		for _, m := range directiveRe.FindAllStringSubmatch(text, -1) {
+			ids := parseDirectiveIDs(m[2])
+			switch m[1] {
+			case "disable":
+				for id := range ids {
+					active[id] = true
+				}
+			case "enable":
+				if ids[allRules] {
+					active = make(map[string]bool)
+				}
+				for id := range ids {
+					delete(active, id)
+				}
+			case "disable-line":
+				add(lineNo, ids)
+			case "disable-next-line":
+				add(lineNo+1, ids)
+			}
+		}
+		add(lineNo, active)
+	}
+	return s
+}
+
+func parseDirectiveIDs(spec string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, f := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		ids[strings.ToLower(f)] = true
+	}
+	if len(ids) == 0 {
+		ids[allRules] = true
+	}
+	return ids
+}
+
+// suppressed reports whether a finding from ruleID at line is disabled
+func (s *suppressions) suppressed(ruleID string, line int) bool {
+	if line == 0 {
+		line = 1
+	}
+	ids := s.lines[line]
+	return ids[allRules] || ids[ruleID]
+}
@@ -0,0 +1,319 @@
+// Package lint checks SKILL.md files against a set of registered rules.
+//
+// Each rule has a stable ID, a default severity and documentation. A
+// project can tune rules in .openskill-lint.yaml:
+//
+//	rules:
+//	  vague-language:
+//	    severity: error
+//	    options:
+//	      phrases: ["be good", "as needed"]
+//	  too-many-rules:
+//	    options:
+//	      max: 30
+//	  name-format: off
+//
+// and silence individual findings with inline directives (see Document).
+package lint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the project lint configuration, relative to the working directory
+const ConfigFile = ".openskill-lint.yaml"
+
+// Severity of a finding
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off" // Only valid in configuration
+)
+
+// ParseSeverity validates a severity name
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return sev, nil
+	}
+	return "", fmt.Errorf("invalid severity '%s' (valid: error, warning, info, off)", s)
+}
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	RuleID   string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+	Path     string   `json:"path" yaml:"path"`
+	Line     int      `json:"line" yaml:"line"`
+	Column   int      `json:"column" yaml:"column"`
+}
+
+// String formats a finding as path:line:col: severity: message [rule]
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", f.Path, f.Line, f.Column, f.Severity, f.Message, f.RuleID)
+}
+
+// ============== Rule registry ==============
+
+// Rule is a registered lint check
+type Rule struct {
+	ID              string
+	DefaultSeverity Severity
+	Summary         string                 // One line shown in rule listings
+	Docs            string                 // Longer explanation shown by `validate --explain`
+	Options         map[string]interface{} // Default option values
+	Check           func(r *Run)
+}
+
+var registry = make(map[string]*Rule)
+
+// Register adds a rule to the registry. It panics on duplicate IDs, so
+// rules are expected to register from init functions.
+func Register(rule Rule) {
+	if rule.ID == "" || rule.Check == nil {
+		panic("lint: rule needs an ID and a Check function")
+	}
+	if _, exists := registry[rule.ID]; exists {
+		panic(fmt.Sprintf("lint: rule '%s' registered twice", rule.ID))
+	}
+	r := rule
+	registry[rule.ID] = &r
+}
+
+// Rules returns all registered rules ordered by ID
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, r := range registry {
+		rules = append(rules, *r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// LookupRule finds a registered rule by ID
+func LookupRule(id string) (Rule, bool) {
+	r, ok := registry[strings.ToLower(id)]
+	if !ok {
+		return Rule{}, false
+	}
+	return *r, true
+}
+
+// ============== Configuration ==============
+
+// Config is the contents of .openskill-lint.yaml
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig tunes a single rule. In YAML it may also be written as a
+// bare severity ("vague-language: error") or "off".
+type RuleConfig struct {
+	Enabled  *bool                  `yaml:"enabled,omitempty"`
+	Severity Severity               `yaml:"severity,omitempty"`
+	Options  map[string]interface{} `yaml:"options,omitempty"`
+}
+
+// UnmarshalYAML accepts either a mapping or a bare severity
+func (c *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!bool" {
+			var enabled bool
+			if err := node.Decode(&enabled); err != nil {
+				return err
+			}
+			c.Enabled = &enabled
+			return nil
+		}
+		c.Severity = Severity(node.Value)
+		return nil
+	}
+	type plain RuleConfig
+	return node.Decode((*plain)(c))
+}
+
+// LoadConfig reads a lint config file. A missing file yields the default
+// configuration.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{Rules: make(map[string]RuleConfig)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Rules == nil {
+		cfg.Rules = make(map[string]RuleConfig)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate checks that the config only names known rules and severities
+func (c *Config) Validate() error {
+	for id, rc := range c.Rules {
+		if _, ok := registry[id]; !ok {
+			return fmt.Errorf("unknown rule '%s'", id)
+		}
+		if rc.Severity != "" {
+			if _, err := ParseSeverity(string(rc.Severity)); err != nil {
+				return fmt.Errorf("rule '%s': %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Severity returns the severity a rule runs at, or "" if it is disabled
+func (c *Config) Severity(rule Rule) Severity {
+	sev := rule.DefaultSeverity
+	if c == nil {
+		return sev
+	}
+	rc, ok := c.Rules[rule.ID]
+	if !ok {
+		return sev
+	}
+	if rc.Enabled != nil && !*rc.Enabled {
+		return ""
+	}
+	if rc.Severity != "" {
+		sev = Severity(strings.ToLower(string(rc.Severity)))
+	}
+	if sev == SeverityOff {
+		return ""
+	}
+	return sev
+}
+
+// ============== Running ==============
+
+// Options controls a lint run
+type Options struct {
+	Config *Config
+
+	// SkillExists resolves references to other skills. When nil,
+	// dangling-reference checks are skipped.
+	SkillExists func(name string) bool
+}
+
+// Run is handed to a rule's Check function
+type Run struct {
+	Doc  *Document
+	Opts Options
+
+	rule     *Rule
+	options  map[string]interface{}
+	findings []Finding
+}
+
+// Report records a finding at a position
+func (r *Run) Report(pos Position, format string, args ...interface{}) {
+	r.findings = append(r.findings, Finding{
+		RuleID:  r.rule.ID,
+		Message: fmt.Sprintf(format, args...),
+		Path:    r.Doc.Path,
+		Line:    pos.Line,
+		Column:  pos.Column,
+	})
+}
+
+// Int returns an integer option, falling back to the rule default
+func (r *Run) Int(name string) int {
+	switch v := r.option(name).(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// Strings returns a list option, falling back to the rule default
+func (r *Run) Strings(name string) []string {
+	switch v := r.option(name).(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out
+	case string:
+		return []string{v}
+	}
+	return nil
+}
+
+func (r *Run) option(name string) interface{} {
+	if v, ok := r.options[name]; ok {
+		return v
+	}
+	return r.rule.Options[name]
+}
+
+// Lint runs every enabled rule against doc and returns findings ordered
+// by position. Findings silenced by inline directives are dropped.
+func Lint(doc *Document, opts Options) []Finding {
+	var findings []Finding
+
+	for _, rule := range Rules() {
+		rule := rule
+		sev := opts.Config.Severity(rule)
+		if sev == "" {
+			continue
+		}
+
+		run := &Run{Doc: doc, Opts: opts, rule: &rule}
+		if opts.Config != nil {
+			run.options = opts.Config.Rules[rule.ID].Options
+		}
+		rule.Check(run)
+
+		for _, f := range run.findings {
+			if doc.suppressions.suppressed(rule.ID, f.Line) {
+				continue
+			}
+			f.Severity = sev
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+	return findings
+}
+
+// HasErrors reports whether any finding is an error
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package lint
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// FileResult is the outcome of linting one skill
+type FileResult struct {
+	Skill    string
+	Path     string
+	Findings []Finding
+}
+
+// Failed reports whether the result should fail a build. In strict mode
+// warnings fail too.
+func (r FileResult) Failed(strict bool) bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError || (strict && f.Severity == SeverityWarning) {
+			return true
+		}
+	}
+	return false
+}
+
+// ============== SARIF ==============
+
+// SARIF 2.1.0, trimmed to the properties code-scanning tools read
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// WriteSARIF writes results as a SARIF 2.1.0 log
+func WriteSARIF(w io.Writer, version string, results []FileResult) error {
+	rules := Rules()
+	index := make(map[string]int, len(rules))
+	driver := sarifDriver{
+		Name:           "openskill",
+		Version:        version,
+		InformationURI: "https://github.com/Rakshit-gen/openskill",
+		Rules:          make([]sarifRule, 0, len(rules)),
+	}
+	for i, r := range rules {
+		index[r.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifMessage{Text: r.Summary},
+			FullDescription:      sarifMessage{Text: r.Docs},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(r.DefaultSeverity)},
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, res := range results {
+		for _, f := range res.Findings {
+			line := f.Line
+			if line < 1 {
+				line = 1
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    f.RuleID,
+				RuleIndex: index[f.RuleID],
+				Level:     sarifLevel(f.Severity),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.Path)},
+					Region:           sarifRegion{StartLine: line, StartColumn: f.Column},
+				}}},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Schema: sarifSchema, Version: "2.1.0", Runs: []sarifRun{run}})
+}
+
+// ============== JUnit ==============
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes one test case per skill. A skill fails when it has
+// errors, or warnings in strict mode; other findings go to system-out.
+func WriteJUnit(w io.Writer, results []FileResult, strict bool) error {
+	suite := junitTestSuite{Name: "openskill validate", Tests: len(results)}
+
+	for _, res := range results {
+		tc := junitTestCase{Name: res.Skill, Classname: "openskill.validate", File: filepath.ToSlash(res.Path)}
+
+		var failing, other []string
+		for _, f := range res.Findings {
+			line := f.String()
+			if f.Severity == SeverityError || (strict && f.Severity == SeverityWarning) {
+				failing = append(failing, line)
+			} else {
+				other = append(other, line)
+			}
+		}
+
+		if len(failing) > 0 {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d finding(s)", len(failing)),
+				Type:    "validation",
+				Text:    strings.Join(failing, "\n"),
+			}
+		}
+		if len(other) > 0 {
+			tc.SystemOut = strings.Join(other, "\n")
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	doc := junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSuite{suite}}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
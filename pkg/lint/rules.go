@@ -0,0 +1,528 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in rules
+func init() {
+	Register(Rule{
+		ID:              "frontmatter-syntax",
+		DefaultSeverity: SeverityError,
+		Summary:         "SKILL.md must start with a closed, valid YAML frontmatter block",
+		Docs: `SKILL.md files begin with YAML frontmatter between two '---' lines.
+This rule reports a missing or unclosed block and YAML syntax errors.
+No other frontmatter rule can run until it passes.`,
+		Check: checkFrontmatterSyntax,
+	})
+
+	Register(Rule{
+		ID:              "frontmatter-schema",
+		DefaultSeverity: SeverityError,
+		Summary:         "Frontmatter values must have the type the skill schema expects",
+		Docs: `Checks each frontmatter value against the skill schema. Values of the
+wrong type (for example a string where a list of tags is expected) stop
+the skill from loading and are reported at the value.`,
+		Check: checkFrontmatterSchema,
+	})
+
+	Register(Rule{
+		ID:              "unknown-key",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Frontmatter keys must be part of the skill schema",
+		Docs:            `Unknown frontmatter keys are ignored by openskill and are usually typos (e.g. 'tag' for 'tags').`,
+		Check:           checkUnknownKeys,
+	})
+
+	Register(Rule{
+		ID:              "required-fields",
+		DefaultSeverity: SeverityError,
+		Summary:         "Skills need a name and a description",
+		Docs:            `The name identifies the skill and the description tells agents when to apply it. Both are required.`,
+		Check:           checkRequiredFields,
+	})
+
+	Register(Rule{
+		ID:              "name-format",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Skill names should be short, hyphenated and match their directory",
+		Docs: `Names with spaces are awkward on the command line; prefer hyphens
+(e.g. 'code-review'). Long names are hard to remember. The name should
+also match the skill's directory, otherwise lookups by name fail.
+
+Options:
+  max-length  longest allowed name (default 50)`,
+		Options: map[string]interface{}{"max-length": 50},
+		Check:   checkNameFormat,
+	})
+
+	Register(Rule{
+		ID:              "description-length",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Descriptions should be neither too short nor too long",
+		Docs: `A description that is too short does not say when the skill applies;
+one that is too long wastes context.
+
+Options:
+  min  shortest allowed description (default 10)
+  max  longest allowed description (default 500)`,
+		Options: map[string]interface{}{"min": 10, "max": 500},
+		Check:   checkDescriptionLength,
+	})
+
+	Register(Rule{
+		ID:              "no-rules",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Skills should define at least one rule",
+		Docs:            `Skills work better with specific behavioral rules listed under '## Rules'. Skills that only extend or include others are exempt.`,
+		Check:           checkNoRules,
+	})
+
+	Register(Rule{
+		ID:              "empty-rule",
+		DefaultSeverity: SeverityError,
+		Summary:         "Rule list items must not be empty",
+		Docs:            `An empty '- ' item under '## Rules' is dropped when the skill is loaded and is almost always a mistake.`,
+		Check:           checkEmptyRule,
+	})
+
+	Register(Rule{
+		ID:              "rule-length",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Rules should be specific but not rambling",
+		Docs: `Very short rules are rarely specific enough to act on. Very long rules
+usually combine several instructions and should be split.
+
+Options:
+  min  shortest allowed rule (default 10)
+  max  longest allowed rule (default 500)`,
+		Options: map[string]interface{}{"min": 10, "max": 500},
+		Check:   checkRuleLength,
+	})
+
+	Register(Rule{
+		ID:              "too-many-rules",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Skills with many rules should be consolidated or split",
+		Docs: `Long rule lists dilute each rule's weight. Consolidate related rules or
+split the skill and combine the parts with 'includes'.
+
+Options:
+  max  most rules allowed (default 20)`,
+		Options: map[string]interface{}{"max": 20},
+		Check:   checkTooManyRules,
+	})
+
+	Register(Rule{
+		ID:              "vague-language",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Rules should use specific, actionable instructions",
+		Docs: `Phrases like "be good", "best practices" or "where possible" give an
+agent nothing concrete to follow. Say what good looks like instead
+("Return errors instead of panicking" rather than "Handle errors properly").
+Checked in rules and the description.
+
+Options:
+  phrases  case-insensitive phrases to flag (replaces the default list)`,
+		Options: map[string]interface{}{"phrases": []string{
+			"be good", "be nice", "be careful", "best practices", "clean code",
+			"as needed", "as appropriate", "when appropriate", "if needed",
+			"if possible", "where possible", "try to", "etc.", "and so on",
+		}},
+		Check: checkVagueLanguage,
+	})
+
+	Register(Rule{
+		ID:              "duplicate-rule",
+		DefaultSeverity: SeverityWarning,
+		Summary:         "Rules should not repeat each other",
+		Docs:            `Reports rules that are identical to an earlier rule, ignoring case, punctuation and spacing.`,
+		Check:           checkDuplicateRule,
+	})
+
+	Register(Rule{
+		ID:              "dangling-reference",
+		DefaultSeverity: SeverityError,
+		Summary:         "extends, includes and chain must name existing skills",
+		Docs: `A reference to a skill that does not exist is silently skipped when
+the skill is composed. References to the skill itself are reported too.`,
+		Check: checkDanglingReference,
+	})
+}
+
+// ============== Frontmatter ==============
+
+func checkFrontmatterSyntax(r *Run) {
+	if r.Doc.FrontmatterErr != nil {
+		r.Report(r.Doc.FrontmatterErrPos, "%v", r.Doc.FrontmatterErr)
+	}
+}
+
+// fieldKind describes the expected shape of a frontmatter value
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindList
+	kindStringMap
+	kindObject
+	kindObjectList
+)
+
+type schemaField struct {
+	kind   fieldKind
+	fields map[string]schemaField // For kindObject and kindObjectList
+}
+
+var skillSchema = map[string]schemaField{
+	"name":          {kind: kindString},
+	"description":   {kind: kindString},
+	"extends":       {kind: kindString},
+	"includes":      {kind: kindList},
+	"tags":          {kind: kindList},
+	"group":         {kind: kindString},
+	"template":      {kind: kindString},
+	"variables":     {kind: kindStringMap},
+	"author":        {kind: kindString},
+	"version":       {kind: kindString},
+	"output_format": {kind: kindString},
+	"chain":         {kind: kindList},
+	"context": {kind: kindObject, fields: map[string]schemaField{
+		"files":       {kind: kindList},
+		"globs":       {kind: kindList},
+		"commands":    {kind: kindList},
+		"urls":        {kind: kindList},
+		"environment": {kind: kindList},
+	}},
+	"hooks": {kind: kindObject, fields: map[string]schemaField{
+		"pre":  {kind: kindList},
+		"post": {kind: kindList},
+	}},
+	"examples": {kind: kindObjectList, fields: map[string]schemaField{
+		"input":  {kind: kindString},
+		"output": {kind: kindString},
+	}},
+}
+
+var outputFormats = []string{"markdown", "json", "code"}
+
+func checkFrontmatterSchema(r *Run) {
+	doc := r.Doc
+	if doc.Frontmatter == nil {
+		return
+	}
+
+	before := len(r.findings)
+	checkMapping(r, doc.Frontmatter, skillSchema, "")
+
+	if _, value := doc.Key("output_format"); value != nil && value.Kind == yaml.ScalarNode && value.Value != "" {
+		if !containsString(outputFormats, value.Value) {
+			r.Report(doc.NodePos(value), "unknown output_format '%s' (expected one of: %s)", value.Value, strings.Join(outputFormats, ", "))
+		}
+	}
+
+	// The schema walk should explain any decode failure; fall back to the
+	// decoder's message if it did not
+	if doc.DecodeErr != nil && len(r.findings) == before {
+		r.Report(Position{Line: 1, Column: 1}, "%v", doc.DecodeErr)
+	}
+}
+
+func checkMapping(r *Run, node *yaml.Node, schema map[string]schemaField, prefix string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		name := prefix + key.Value
+
+		field, ok := schema[key.Value]
+		if !ok || isNull(value) {
+			continue
+		}
+
+		switch field.kind {
+		case kindString:
+			if value.Kind != yaml.ScalarNode {
+				r.Report(r.Doc.NodePos(value), "'%s' must be a string", name)
+			}
+		case kindList:
+			if value.Kind != yaml.SequenceNode {
+				r.Report(r.Doc.NodePos(value), "'%s' must be a list", name)
+				continue
+			}
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					r.Report(r.Doc.NodePos(item), "items of '%s' must be strings", name)
+				}
+			}
+		case kindStringMap:
+			if value.Kind != yaml.MappingNode {
+				r.Report(r.Doc.NodePos(value), "'%s' must be a mapping of names to values", name)
+				continue
+			}
+			for j := 1; j < len(value.Content); j += 2 {
+				if value.Content[j].Kind != yaml.ScalarNode {
+					r.Report(r.Doc.NodePos(value.Content[j]), "'%s.%s' must be a string", name, value.Content[j-1].Value)
+				}
+			}
+		case kindObject:
+			if value.Kind != yaml.MappingNode {
+				r.Report(r.Doc.NodePos(value), "'%s' must be a mapping", name)
+				continue
+			}
+			checkMapping(r, value, field.fields, name+".")
+		case kindObjectList:
+			if value.Kind != yaml.SequenceNode {
+				r.Report(r.Doc.NodePos(value), "'%s' must be a list", name)
+				continue
+			}
+			for _, item := range value.Content {
+				if item.Kind != yaml.MappingNode {
+					r.Report(r.Doc.NodePos(item), "items of '%s' must be mappings", name)
+					continue
+				}
+				checkMapping(r, item, field.fields, name+"[].")
+			}
+		}
+	}
+}
+
+func checkUnknownKeys(r *Run) {
+	if r.Doc.Frontmatter != nil {
+		reportUnknownKeys(r, r.Doc.Frontmatter, skillSchema, "")
+	}
+}
+
+func reportUnknownKeys(r *Run, node *yaml.Node, schema map[string]schemaField, prefix string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := schema[key.Value]
+		if !ok {
+			r.Report(r.Doc.NodePos(key), "unknown frontmatter key '%s'", prefix+key.Value)
+			continue
+		}
+		if field.kind == kindObject && value.Kind == yaml.MappingNode {
+			reportUnknownKeys(r, value, field.fields, prefix+key.Value+".")
+		}
+		if field.kind == kindObjectList && value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				if item.Kind == yaml.MappingNode {
+					reportUnknownKeys(r, item, field.fields, prefix+key.Value+"[].")
+				}
+			}
+		}
+	}
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func checkRequiredFields(r *Run) {
+	skill := r.Doc.Skill
+	if skill == nil {
+		return
+	}
+	if strings.TrimSpace(skill.Name) == "" {
+		r.Report(r.Doc.KeyPos("name"), "missing required field: name")
+	}
+	if strings.TrimSpace(skill.Description) == "" {
+		r.Report(r.Doc.KeyPos("description"), "missing required field: description")
+	}
+}
+
+func checkNameFormat(r *Run) {
+	skill := r.Doc.Skill
+	if skill == nil || skill.Name == "" {
+		return
+	}
+	pos := r.Doc.ValuePos("name")
+
+	if strings.Contains(skill.Name, " ") {
+		r.Report(pos, "skill name contains spaces; consider hyphens (e.g. 'code-review')")
+	}
+	if max := r.Int("max-length"); max > 0 && len(skill.Name) > max {
+		r.Report(pos, "skill name is %d characters (max %d); consider a shorter, more memorable name", len(skill.Name), max)
+	}
+
+	if r.Doc.Path != "" {
+		dir := filepath.Base(filepath.Dir(r.Doc.Path))
+		expected := strings.ToLower(strings.ReplaceAll(skill.Name, " ", "-"))
+		if dir != "." && dir != expected {
+			r.Report(pos, "skill name '%s' does not match its directory '%s'", skill.Name, dir)
+		}
+	}
+}
+
+func checkDescriptionLength(r *Run) {
+	skill := r.Doc.Skill
+	if skill == nil || skill.Description == "" {
+		return
+	}
+	pos := r.Doc.ValuePos("description")
+	n := len(skill.Description)
+	if min := r.Int("min"); n < min {
+		r.Report(pos, "description is very short (%d characters, min %d); add more detail", n, min)
+	}
+	if max := r.Int("max"); max > 0 && n > max {
+		r.Report(pos, "description is very long (%d characters, max %d); be more concise", n, max)
+	}
+}
+
+// ============== Rules section ==============
+
+func checkNoRules(r *Run) {
+	skill := r.Doc.Skill
+	if skill == nil || len(skill.Rules) > 0 || skill.Extends != "" || len(skill.Includes) > 0 {
+		return
+	}
+	r.Report(Position{Line: 1, Column: 1}, "no rules defined; skills work better with specific behavioral rules")
+}
+
+func checkEmptyRule(r *Run) {
+	for i, rule := range r.Doc.Rules {
+		if strings.TrimSpace(rule.Text) == "" {
+			r.Report(Position{Line: rule.Pos.Line, Column: 1}, "rule %d is empty", i+1)
+		}
+	}
+}
+
+func checkRuleLength(r *Run) {
+	min, max := r.Int("min"), r.Int("max")
+	for i, rule := range r.Doc.Rules {
+		text := strings.TrimSpace(rule.Text)
+		if text == "" {
+			continue
+		}
+		if len(text) < min {
+			r.Report(rule.Pos, "rule %d is very short; be more specific", i+1)
+		}
+		if max > 0 && len(text) > max {
+			r.Report(rule.Pos, "rule %d is very long (%d characters); consider splitting it", i+1, len(text))
+		}
+	}
+}
+
+func checkTooManyRules(r *Run) {
+	max := r.Int("max")
+	if max <= 0 || len(r.Doc.Rules) <= max {
+		return
+	}
+	// Point at the first rule over the limit
+	r.Report(r.Doc.Rules[max].Pos, "%d rules defined (max %d); consider consolidating related rules", len(r.Doc.Rules), max)
+}
+
+func checkVagueLanguage(r *Run) {
+	var patterns []*regexp.Regexp
+	var phrases []string
+	for _, phrase := range r.Strings("phrases") {
+		phrase = strings.TrimSpace(phrase)
+		if phrase == "" {
+			continue
+		}
+		patterns = append(patterns, phrasePattern(phrase))
+		phrases = append(phrases, phrase)
+	}
+
+	scan := func(text string, pos Position, what string) {
+		for i, re := range patterns {
+			if loc := re.FindStringIndex(text); loc != nil {
+				r.Report(Position{Line: pos.Line, Column: pos.Column + loc[0]}, "%s uses vague language ('%s'); use a specific, actionable instruction", what, phrases[i])
+			}
+		}
+	}
+
+	if _, value := r.Doc.Key("description"); value != nil && value.Kind == yaml.ScalarNode && value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		// Columns are only exact for plain, single-line scalars
+		pos := r.Doc.NodePos(value)
+		if value.Style != 0 {
+			pos.Column++ // Skip the opening quote
+		}
+		scan(value.Value, pos, "description")
+	}
+
+	for i, rule := range r.Doc.Rules {
+		scan(rule.Text, rule.Pos, fmt.Sprintf("rule %d", i+1))
+	}
+}
+
+// phrasePattern matches a phrase case-insensitively on word boundaries
+func phrasePattern(phrase string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(phrase)
+	if r := rune(phrase[0]); unicode.IsLetter(r) || unicode.IsDigit(r) {
+		expr = `\b` + expr
+	}
+	if r := rune(phrase[len(phrase)-1]); unicode.IsLetter(r) || unicode.IsDigit(r) {
+		expr = expr + `\b`
+	}
+	return regexp.MustCompile(`(?i)` + expr)
+}
+
+var nonWordRe = regexp.MustCompile(`[^\pL\pN]+`)
+
+func checkDuplicateRule(r *Run) {
+	first := make(map[string]RuleLine)
+	for i, rule := range r.Doc.Rules {
+		key := strings.TrimSpace(nonWordRe.ReplaceAllString(strings.ToLower(rule.Text), " "))
+		if key == "" {
+			continue
+		}
+		if prev, ok := first[key]; ok {
+			r.Report(rule.Pos, "rule %d duplicates the rule on line %d", i+1, prev.Pos.Line)
+			continue
+		}
+		first[key] = rule
+	}
+}
+
+// ============== References ==============
+
+func checkDanglingReference(r *Run) {
+	skill := r.Doc.Skill
+	if skill == nil || r.Opts.SkillExists == nil {
+		return
+	}
+
+	check := func(field string, node *yaml.Node) {
+		name := strings.TrimSpace(node.Value)
+		if name == "" {
+			return
+		}
+		switch {
+		case strings.EqualFold(name, skill.Name):
+			r.Report(r.Doc.NodePos(node), "'%s' references the skill itself", field)
+		case !r.Opts.SkillExists(name):
+			r.Report(r.Doc.NodePos(node), "'%s' references unknown skill '%s'", field, name)
+		}
+	}
+
+	for _, field := range []string{"extends", "includes", "chain"} {
+		_, value := r.Doc.Key(field)
+		if value == nil {
+			continue
+		}
+		switch value.Kind {
+		case yaml.ScalarNode:
+			check(field, value)
+		case yaml.SequenceNode:
+			for _, item := range value.Content {
+				if item.Kind == yaml.ScalarNode {
+					check(field, item)
+				}
+			}
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -2,9 +2,11 @@ package llm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"openskill/pkg/config"
 )
@@ -16,6 +18,14 @@ type AnthropicClient struct {
 	endpoint string
 }
 
+func init() {
+	RegisterProvider(ProviderInfo{
+		Name:        string(ProviderAnthropic),
+		Description: "Anthropic Messages API",
+		New:         func() Provider { return NewAnthropicClient() },
+	})
+}
+
 // NewAnthropicClient creates a new Anthropic client
 func NewAnthropicClient() *AnthropicClient {
 	return &AnthropicClient{
@@ -29,15 +39,44 @@ func (c *AnthropicClient) Name() string {
 	return "Anthropic"
 }
 
+func (c *AnthropicClient) Model() string {
+	return c.model
+}
+
 func (c *AnthropicClient) IsConfigured() bool {
 	return c.apiKey != ""
 }
 
+// anthropicVersion is the Messages API version sent with every request
+const anthropicVersion = "2023-06-01"
+
+// anthropicMaxTokens is used when a request doesn't set MaxTokens; the API requires one
+const anthropicMaxTokens = 4096
+
 // Anthropic-specific request/response types
 type anthropicRequest struct {
-	Model     string             `json:"model"`
-	MaxTokens int                `json:"max_tokens"`
-	Messages  []anthropicMessage `json:"messages"`
+	Model         string             `json:"model"`
+	MaxTokens     int                `json:"max_tokens"`
+	System        string             `json:"system,omitempty"`
+	Messages      []anthropicMessage `json:"messages"`
+	Temperature   *float64           `json:"temperature,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool    `json:"tools,omitempty"`
+	ToolChoice    *anthropicChoice   `json:"tool_choice,omitempty"`
+	Stream        bool               `json:"stream,omitempty"`
+}
+
+// anthropicTool carries a Format schema; forcing the model to call the
+// tool makes its input the structured reply
+type anthropicTool struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	InputSchema *Schema `json:"input_schema"`
+}
+
+type anthropicChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
 type anthropicMessage struct {
@@ -46,51 +85,202 @@ type anthropicMessage struct {
 }
 
 type anthropicResponse struct {
+	Model   string `json:"model"`
 	Content []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Input json.RawMessage `json:"input,omitempty"` // tool_use blocks
 	} `json:"content"`
-	Error *struct {
+	StopReason string          `json:"stop_reason"`
+	Usage      *anthropicUsage `json:"usage,omitempty"`
+	Error      *struct {
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
 }
 
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
 func (c *AnthropicClient) Generate(prompt string) (string, error) {
-	req := anthropicRequest{
-		Model:     c.model,
-		MaxTokens: 4096,
-		Messages: []anthropicMessage{
-			{Role: "user", Content: prompt},
-		},
+	return generate(c, prompt)
+}
+
+// newAnthropicRequest converts a Request to the Messages API format.
+// System messages move to the top-level system field.
+func newAnthropicRequest(model string, req Request) anthropicRequest {
+	out := anthropicRequest{
+		Model:         model,
+		MaxTokens:     req.MaxTokens,
+		System:        systemPrompt(req),
+		Temperature:   req.Temperature,
+		StopSequences: req.Stop,
+	}
+	if out.MaxTokens <= 0 {
+		out.MaxTokens = anthropicMaxTokens
+	}
+	if req.Format != nil {
+		out.Tools = []anthropicTool{{
+			Name:        req.Format.Name,
+			Description: "Submit the response as structured data",
+			InputSchema: req.Format.Schema,
+		}}
+		out.ToolChoice = &anthropicChoice{Type: "tool", Name: req.Format.Name}
 	}
+	for _, m := range req.Messages {
+		if m.Role == RoleSystem {
+			continue
+		}
+		out.Messages = append(out.Messages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
 
-	body, _ := json.Marshal(req)
-	httpReq, _ := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(body))
+// post sends a Messages API request and turns non-200 responses into
+// errors. The caller closes the response body.
+func (c *AnthropicClient) post(ctx context.Context, payload anthropicRequest) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
 	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("anthropic-version", "2023-06-01")
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, networkError(ctx, c.Name(), err)
+	}
+	if resp.StatusCode == 200 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var result anthropicResponse
+	message := ""
+	if json.NewDecoder(resp.Body).Decode(&result) == nil && result.Error != nil {
+		message = result.Error.Message
+	}
+	return nil, newAPIError(c.Name(), resp, message)
+}
+
+func (c *AnthropicClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.post(ctx, newAnthropicRequest(c.model, req))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var result anthropicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	out := &Response{Model: result.Model, FinishReason: result.StopReason}
+	if result.Usage != nil {
+		out.Usage = Usage{PromptTokens: result.Usage.InputTokens, CompletionTokens: result.Usage.OutputTokens}
 	}
 
-	if resp.StatusCode != 200 {
-		if result.Error != nil {
-			return "", fmt.Errorf("Anthropic API error: %s", result.Error.Message)
+	var text strings.Builder
+	for _, block := range result.Content {
+		switch {
+		case block.Type == "text":
+			text.WriteString(block.Text)
+		case block.Type == "tool_use" && req.Format != nil:
+			// The forced tool call's input is the structured reply
+			out.Content = string(block.Input)
+			return out, nil
 		}
-		return "", fmt.Errorf("Anthropic API error: %s", resp.Status)
 	}
+	if text.Len() == 0 {
+		return nil, fmt.Errorf("no response from Anthropic")
+	}
+
+	out.Content = text.String()
+	return out, nil
+}
+
+// anthropicStreamEvent covers the fields used from message_start,
+// content_block_delta, message_delta and error events
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message *struct {
+		Model string          `json:"model"`
+		Usage *anthropicUsage `json:"usage,omitempty"`
+	} `json:"message,omitempty"`
+	Delta *struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta,omitempty"`
+	Usage *anthropicUsage `json:"usage,omitempty"` // message_delta carries output tokens
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (c *AnthropicClient) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	payload := newAnthropicRequest(c.model, req)
+	payload.Stream = true
+	resp, err := c.post(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	if len(result.Content) == 0 {
-		return "", fmt.Errorf("no response from Anthropic")
+	out := &Response{Model: c.model}
+	var content strings.Builder
+	err = readSSE(resp.Body, func(event, data string) error {
+		var ev anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			return fmt.Errorf("invalid Anthropic stream event: %w", err)
+		}
+		switch ev.Type {
+		case "message_start":
+			if ev.Message != nil && ev.Message.Model != "" {
+				out.Model = ev.Message.Model
+			}
+			if ev.Message != nil && ev.Message.Usage != nil {
+				out.Usage.PromptTokens = ev.Message.Usage.InputTokens
+			}
+		case "content_block_delta":
+			if ev.Delta == nil || ev.Delta.Type != "text_delta" || ev.Delta.Text == "" {
+				return nil
+			}
+			content.WriteString(ev.Delta.Text)
+			return fn(ev.Delta.Text)
+		case "message_delta":
+			if ev.Delta != nil && ev.Delta.StopReason != "" {
+				out.FinishReason = ev.Delta.StopReason
+			}
+			if ev.Usage != nil {
+				out.Usage.CompletionTokens = ev.Usage.OutputTokens
+			}
+		case "message_stop":
+			return errStreamDone
+		case "error":
+			if ev.Error != nil {
+				return fmt.Errorf("Anthropic API error: %s", ev.Error.Message)
+			}
+			return fmt.Errorf("Anthropic API error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result.Content[0].Text, nil
+	out.Content = content.String()
+	return out, nil
 }
@@ -0,0 +1,441 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// CacheMode controls how providers use the response cache
+type CacheMode int
+
+const (
+	CacheOn   CacheMode = iota // Serve hits, store misses
+	CacheOff                   // Always call the provider (--no-cache)
+	CacheOnly                  // Never call the provider; misses fail (--cache-only)
+)
+
+// Caching is the mode applied by GetProviderByName; set from global flags
+var Caching = CacheOn
+
+// Cache defaults, overridable with cache_ttl and cache_max_mb
+const (
+	DefaultCacheTTL   = 24 * time.Hour
+	DefaultCacheMaxMB = 50
+)
+
+// Put prunes the cache when it was last pruned longer than pruneInterval
+// ago, or once this process has written a tenth of MaxBytes since, rather
+// than walking the whole cache on every write
+const (
+	pruneInterval = time.Hour
+	pruneStamp    = ".pruned" // Touched after each prune
+)
+
+// pruneState tracks writes since this process last pruned
+var pruneState struct {
+	sync.Mutex
+	written int64
+}
+
+// ErrCacheMiss is returned in CacheOnly mode when nothing is cached
+var ErrCacheMiss = errors.New("no cached response for this request (--cache-only)")
+
+// Cache stores completed responses on disk, one file per request
+type Cache struct {
+	Dir      string
+	TTL      time.Duration // Zero disables the cache
+	MaxBytes int64
+}
+
+// OpenCache returns the cache under ~/.openskill/cache with limits from the config
+func OpenCache() (*Cache, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		Dir:      filepath.Join(dir, "cache"),
+		TTL:      DefaultCacheTTL,
+		MaxBytes: DefaultCacheMaxMB << 20,
+	}
+	if ttl, ok := config.GetCacheTTL(); ok {
+		c.TTL = ttl
+	}
+	if mb, ok := config.GetCacheMaxMB(); ok {
+		c.MaxBytes = int64(mb) << 20
+	}
+	return c, nil
+}
+
+// Enabled reports whether entries are kept at all
+func (c *Cache) Enabled() bool {
+	return c.TTL > 0
+}
+
+// cacheEntry is the file stored for each request
+type cacheEntry struct {
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	Created  time.Time `json:"created"`
+	Response Response  `json:"response"`
+}
+
+// keyedRequest is the normalized form of a request that gets hashed
+type keyedRequest struct {
+	Provider    string    `json:"provider"`
+	Model       string    `json:"model,omitempty"`
+	System      string    `json:"system,omitempty"`
+	Messages    []Message `json:"messages"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	Format      *Format   `json:"format,omitempty"`
+}
+
+// RequestKey hashes everything that affects a completion: provider,
+// model, messages and options. Line endings and surrounding whitespace
+// in message text are normalized so cosmetic differences still match.
+func RequestKey(provider, model string, req Request) string {
+	data, _ := json.Marshal(normalizedRequest(provider, model, req))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizedRequest(provider, model string, req Request) keyedRequest {
+	k := keyedRequest{
+		Provider:    strings.ToLower(provider),
+		Model:       model,
+		System:      normalizeText(req.System),
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stop:        req.Stop,
+		Format:      req.Format,
+	}
+	for _, m := range req.Messages {
+		k.Messages = append(k.Messages, Message{Role: m.Role, Content: normalizeText(m.Content)})
+	}
+	return k
+}
+
+func normalizeText(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
+
+// Get returns the cached response for key, if present and fresh
+func (c *Cache) Get(key string) (*Response, bool) {
+	if !c.Enabled() {
+		return nil, false
+	}
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		os.Remove(path)
+		return nil, false
+	}
+	if time.Since(entry.Created) > c.TTL {
+		os.Remove(path)
+		return nil, false
+	}
+
+	// Touch the file so size-based eviction drops least recently used first
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	resp := entry.Response
+	resp.Cached = true
+	return &resp, true
+}
+
+// Put stores a response, pruning old entries now and then; see pruneInterval
+func (c *Cache) Put(key, provider, model string, resp *Response) error {
+	if !c.Enabled() {
+		return nil
+	}
+	data, err := json.MarshalIndent(cacheEntry{
+		Provider: provider,
+		Model:    model,
+		Created:  time.Now(),
+		Response: *resp,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write then rename so a concurrent reader never sees half an entry;
+	// each writer gets its own temp file
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return c.maybePrune(int64(len(data)))
+}
+
+// maybePrune prunes the cache if it's due; see pruneInterval
+func (c *Cache) maybePrune(written int64) error {
+	pruneState.Lock()
+	defer pruneState.Unlock()
+	pruneState.written += written
+
+	stamp := filepath.Join(c.Dir, pruneStamp)
+	if pruneState.written < c.MaxBytes/10 {
+		if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < pruneInterval {
+			return nil
+		}
+	}
+	pruneState.written = 0
+	if _, err := c.Prune(); err != nil {
+		return err
+	}
+	return os.WriteFile(stamp, nil, 0600)
+}
+
+// CacheStats summarizes the cache contents
+type CacheStats struct {
+	Entries int
+	Expired int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (c *Cache) files() ([]cacheFile, error) {
+	var files []cacheFile
+	err := filepath.WalkDir(c.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, cacheFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// Stats reports the number and size of cached entries
+func (c *Cache) Stats() (CacheStats, error) {
+	var stats CacheStats
+	files, err := c.files()
+	if err != nil {
+		return stats, err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			continue
+		}
+		var entry cacheEntry
+		if json.Unmarshal(data, &entry) != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += f.size
+		if c.TTL > 0 && time.Since(entry.Created) > c.TTL {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || entry.Created.Before(stats.Oldest) {
+			stats.Oldest = entry.Created
+		}
+		if entry.Created.After(stats.Newest) {
+			stats.Newest = entry.Created
+		}
+	}
+	return stats, nil
+}
+
+// Prune removes expired entries, then the least recently used ones until
+// the cache fits in MaxBytes. It returns the number removed.
+func (c *Cache) Prune() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	var kept []cacheFile
+	var total int64
+	for _, f := range files {
+		// Files are written when created and touched on each hit, so a
+		// file older than the TTL holds an entry older than the TTL
+		if c.TTL > 0 && time.Since(f.modTime) > c.TTL {
+			if os.Remove(f.path) == nil {
+				removed++
+			}
+			continue
+		}
+		kept = append(kept, f)
+		total += f.size
+	}
+
+	if c.MaxBytes > 0 && total > c.MaxBytes {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].modTime.Before(kept[j].modTime) })
+		for _, f := range kept {
+			if total <= c.MaxBytes {
+				break
+			}
+			if os.Remove(f.path) == nil {
+				removed++
+				total -= f.size
+			}
+		}
+	}
+	return removed, nil
+}
+
+// Clear removes every cached entry and returns how many there were
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return 0, err
+	}
+	return len(files), nil
+}
+
+// ============== Provider wrapper ==============
+
+// CachedProvider serves repeated requests from a Cache
+type CachedProvider struct {
+	inner Provider
+	cache *Cache
+	mode  CacheMode
+}
+
+// NewCachedProvider wraps p with cache in the given mode
+func NewCachedProvider(p Provider, cache *Cache, mode CacheMode) *CachedProvider {
+	return &CachedProvider{inner: p, cache: cache, mode: mode}
+}
+
+func (c *CachedProvider) Name() string {
+	return c.inner.Name()
+}
+
+func (c *CachedProvider) Model() string {
+	return ModelOf(c.inner)
+}
+
+// Unwrap returns the provider being cached
+func (c *CachedProvider) Unwrap() Provider {
+	return c.inner
+}
+
+// IsConfigured is always true in CacheOnly mode, which needs no credentials
+func (c *CachedProvider) IsConfigured() bool {
+	return c.mode == CacheOnly || c.inner.IsConfigured()
+}
+
+func (c *CachedProvider) Generate(prompt string) (string, error) {
+	return generate(c, prompt)
+}
+
+func (c *CachedProvider) Chat(ctx context.Context, req Request) (*Response, error) {
+	return c.Stream(ctx, req, nil)
+}
+
+// Stream replays a cached reply as a single chunk, or streams from the
+// provider and caches the result
+func (c *CachedProvider) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	key := RequestKey(c.Name(), c.Model(), req)
+	if resp, ok := c.cache.Get(key); ok {
+		if fn != nil {
+			if err := fn(resp.Content); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+	if c.mode == CacheOnly {
+		return nil, fmt.Errorf("%s: %w", c.Name(), ErrCacheMiss)
+	}
+
+	var resp *Response
+	var err error
+	if fn != nil {
+		resp, err = ChatStream(ctx, c.inner, req, fn)
+	} else {
+		resp, err = c.inner.Chat(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A failed write only costs a future cache miss
+	c.cache.Put(key, c.Name(), c.Model(), resp)
+	return resp, nil
+}
+
+// withCache wraps p according to Caching and the configured cache
+func withCache(p Provider) Provider {
+	if Caching == CacheOff {
+		return p
+	}
+	cache, err := OpenCache()
+	if err != nil {
+		return p
+	}
+	if !cache.Enabled() && Caching != CacheOnly {
+		return p
+	}
+	return NewCachedProvider(p, cache, Caching)
+}
+
+// Unwrap returns the provider underneath any wrappers such as the cache
+func Unwrap(p Provider) Provider {
+	for {
+		w, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return p
+		}
+		p = w.Unwrap()
+	}
+}
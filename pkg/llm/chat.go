@@ -0,0 +1,115 @@
+package llm
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Message roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one turn of a conversation
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Request is a provider-neutral chat completion request
+type Request struct {
+	System      string    // System prompt, sent the way each provider expects
+	Messages    []Message // Conversation turns, oldest first
+	Temperature *float64  // Sampling temperature; nil uses the provider default
+	MaxTokens   int       // Completion limit; 0 uses the provider default
+	Stop        []string  // Stop sequences
+	Format      *Format   // Ask for JSON matching a schema; see GenerateJSON
+}
+
+// Response is a completed chat turn
+type Response struct {
+	Content      string
+	Model        string
+	FinishReason string // Provider's reason for stopping, e.g. "stop" or "max_tokens"
+	Usage        Usage  // Token counts, when the provider reports them
+	Cached       bool   // Served from the response cache or a fixture, at no cost
+	Provider     string // Provider that answered, set by FallbackProvider
+}
+
+// Usage is the token count of one completion
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// Temperature returns a pointer for Request.Temperature
+func Temperature(t float64) *float64 {
+	return &t
+}
+
+// PromptRequest wraps a single user prompt in a Request
+func PromptRequest(prompt string) Request {
+	return Request{Messages: []Message{{Role: RoleUser, Content: prompt}}}
+}
+
+// generate implements the legacy Provider.Generate on top of Chat
+func generate(p Provider, prompt string) (string, error) {
+	resp, err := p.Chat(context.Background(), PromptRequest(prompt))
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// systemPrompt joins Request.System with any system messages so providers
+// that take a single system field see all of them
+func systemPrompt(req Request) string {
+	parts := []string{}
+	if req.System != "" {
+		parts = append(parts, req.System)
+	}
+	for _, m := range req.Messages {
+		if m.Role == RoleSystem && m.Content != "" {
+			parts = append(parts, m.Content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ============== HTTP ==============
+
+// RequestTimeout bounds a whole request, including reading the response,
+// when the caller's context has no deadline of its own
+var RequestTimeout = 5 * time.Minute
+
+// HTTPClient is shared by all providers. Requests are cancelled through
+// their context; the transport timeouts catch servers that accept a
+// connection but never answer, and RetryTransport retries transient failures.
+var HTTPClient = &http.Client{
+	Transport: &RetryTransport{
+		Base: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 2 * time.Minute, // Local models can take a while to load
+			IdleConnTimeout:       90 * time.Second,
+			MaxIdleConns:          10,
+		},
+	},
+}
+
+// withTimeout applies RequestTimeout unless ctx already has a deadline
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, RequestTimeout)
+}
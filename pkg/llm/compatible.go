@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"openskill/pkg/config"
+)
+
+// azureAPIVersion is used when an Azure provider doesn't set api_version
+const azureAPIVersion = "2024-06-01"
+
+// CompatibleClient implements the Provider interface for any
+// OpenAI-compatible server configured under custom_providers
+type CompatibleClient struct {
+	name string
+	cfg  config.CustomProvider
+	api  chatAPI
+}
+
+func init() {
+	RegisterProviderSource(customProviders)
+}
+
+// customProviders lists the providers under custom_providers in the config
+func customProviders() []ProviderInfo {
+	var infos []ProviderInfo
+	for _, name := range config.GetCustomProviderNames() {
+		name := name
+		cp, _ := config.GetCustomProvider(name)
+		infos = append(infos, ProviderInfo{
+			Name:        name,
+			Description: "OpenAI-compatible: " + cp.BaseURL,
+			New: func() Provider {
+				cp, _ := config.GetCustomProvider(name)
+				return NewCompatibleClient(name, cp)
+			},
+		})
+	}
+	return infos
+}
+
+// NewCompatibleClient creates a client for a custom provider
+func NewCompatibleClient(name string, cfg config.CustomProvider) *CompatibleClient {
+	headers := map[string]string{}
+	for k, v := range cfg.Headers {
+		headers[k] = os.ExpandEnv(v)
+	}
+
+	return &CompatibleClient{
+		name: name,
+		cfg:  cfg,
+		api: chatAPI{
+			name:    name,
+			url:     compatibleEndpoint(cfg),
+			apiKey:  os.ExpandEnv(cfg.APIKey),
+			model:   config.GetProviderModel(name),
+			headers: headers,
+			azure:   cfg.Deployment != "",
+		},
+	}
+}
+
+func (c *CompatibleClient) Name() string {
+	return c.name
+}
+
+func (c *CompatibleClient) Model() string {
+	return c.api.model
+}
+
+func (c *CompatibleClient) IsConfigured() bool {
+	// The key is optional: local servers like vLLM and LM Studio don't need one
+	return c.cfg.BaseURL != ""
+}
+
+func (c *CompatibleClient) Generate(prompt string) (string, error) {
+	return generate(c, prompt)
+}
+
+func (c *CompatibleClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	return chatCompletion(ctx, c.api, req)
+}
+
+func (c *CompatibleClient) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	return streamChatCompletion(ctx, c.api, req, fn)
+}
+
+// compatibleEndpoint builds the chat completions URL. Plain servers take
+// the OpenAI layout under base_url; Azure routes by deployment and
+// api-version.
+func compatibleEndpoint(cfg config.CustomProvider) string {
+	base := strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.Deployment == "" {
+		if strings.HasSuffix(base, "/chat/completions") {
+			return base
+		}
+		return base + "/chat/completions"
+	}
+
+	version := cfg.APIVersion
+	if version == "" {
+		version = azureAPIVersion
+	}
+	return base + "/openai/deployments/" + url.PathEscape(cfg.Deployment) +
+		"/chat/completions?api-version=" + url.QueryEscape(version)
+}
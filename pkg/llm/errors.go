@@ -0,0 +1,181 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// ErrorKind classifies provider failures so callers can react to them
+type ErrorKind string
+
+const (
+	ErrAuth       ErrorKind = "auth"        // Missing, invalid or unauthorized API key
+	ErrRateLimit  ErrorKind = "rate_limit"  // Too many requests; retry later
+	ErrQuota      ErrorKind = "quota"       // Out of credits or over a billing limit
+	ErrBadRequest ErrorKind = "bad_request" // The request itself was rejected (e.g. unknown model)
+	ErrServer     ErrorKind = "server"      // Provider-side failure or overload
+	ErrNetwork    ErrorKind = "network"     // Could not reach the provider
+)
+
+// APIError is returned by providers for failed requests
+type APIError struct {
+	Provider   string
+	Kind       ErrorKind
+	StatusCode int           // 0 for network errors
+	Status     string        // HTTP status line, e.g. "429 Too Many Requests"
+	Message    string        // Provider's error message, if any
+	RetryAfter time.Duration // How long the provider asked us to wait, if it said
+	Err        error         // Underlying network error
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Kind == ErrNetwork && e.Err != nil:
+		return fmt.Sprintf("%s not reachable: %v", e.Provider, e.Err)
+	case e.Status == "" && e.Message != "":
+		return fmt.Sprintf("%s error: %s", e.Provider, e.Message)
+	case e.Status == "":
+		return fmt.Sprintf("%s error: %s", e.Provider, e.Kind)
+	case e.Message != "":
+		return fmt.Sprintf("%s API error: %s: %s", e.Provider, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s API error: %s", e.Provider, e.Status)
+}
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+// Retryable reports whether trying again later may succeed
+func (e *APIError) Retryable() bool {
+	switch e.Kind {
+	case ErrRateLimit, ErrServer, ErrNetwork:
+		return true
+	}
+	return false
+}
+
+// ModelNotFound reports whether the request was rejected because the
+// model doesn't exist for this provider: unknown, decommissioned or, for
+// Ollama, not pulled. Unlike other bad requests this is a configuration
+// problem another provider won't share.
+func (e *APIError) ModelNotFound() bool {
+	if e.Kind != ErrBadRequest {
+		return false
+	}
+	if e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	m := strings.ToLower(e.Message)
+	if !strings.Contains(m, "model") {
+		return false
+	}
+	for _, s := range []string{"not found", "not_found", "does not exist", "decommissioned", "unknown model", "invalid model"} {
+		if strings.Contains(m, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hint suggests what the user can do about the error
+func (e *APIError) Hint() string {
+	key := strings.ToLower(e.Provider)
+	_, custom := config.GetCustomProvider(key)
+	_, plugin := config.GetPlugin(key)
+	if plugin {
+		switch e.Kind {
+		case ErrAuth, ErrBadRequest:
+			return fmt.Sprintf("%s rejected the request; check its settings under plugins in ~/.openskill/config.yaml", e.Provider)
+		case ErrNetwork:
+			return fmt.Sprintf("Install the %s plugin on your PATH or set its command under plugins in ~/.openskill/config.yaml", e.Provider)
+		}
+	}
+	switch e.Kind {
+	case ErrAuth:
+		if custom {
+			return fmt.Sprintf("Check api_key and headers for %s under custom_providers in ~/.openskill/config.yaml", e.Provider)
+		}
+		return fmt.Sprintf("Check your %s API key: openskill config set %s-api-key", e.Provider, key)
+	case ErrRateLimit:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("%s is rate limiting requests; try again in %s or raise the retry budget with 'openskill config set retry-max-wait'", e.Provider, e.RetryAfter.Round(time.Second))
+		}
+		return fmt.Sprintf("%s is rate limiting requests; wait a moment and try again", e.Provider)
+	case ErrQuota:
+		return fmt.Sprintf("Your %s account is out of credits or over its usage limit; check billing, or switch provider with 'openskill config set provider'", e.Provider)
+	case ErrBadRequest:
+		if custom {
+			return fmt.Sprintf("%s rejected the request; check model and deployment for it under custom_providers in ~/.openskill/config.yaml", e.Provider)
+		}
+		return fmt.Sprintf("%s rejected the request; check the configured model with 'openskill config get %s-model'", e.Provider, key)
+	case ErrServer:
+		return fmt.Sprintf("%s is having problems; try again later or switch provider", e.Provider)
+	case ErrNetwork:
+		if key == "ollama" {
+			return "Make sure Ollama is running ('ollama serve') and the endpoint is correct"
+		}
+		return fmt.Sprintf("Could not reach %s; check your network connection", e.Provider)
+	}
+	return ""
+}
+
+// IsKind reports whether err is an APIError of the given kind
+func IsKind(err error, kind ErrorKind) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Kind == kind
+}
+
+// newAPIError classifies a non-2xx response. message is the provider's
+// error text from the response body, if it could be parsed.
+func newAPIError(provider string, resp *http.Response, message string) *APIError {
+	return &APIError{
+		Provider:   provider,
+		Kind:       classifyStatus(resp.StatusCode, message),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Message:    message,
+		RetryAfter: retryDelay(resp.Header, time.Now()),
+	}
+}
+
+// networkError wraps a transport failure. Cancellation is passed through
+// untouched so callers can still detect Ctrl-C.
+func networkError(ctx context.Context, provider string, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return &APIError{Provider: provider, Kind: ErrNetwork, Err: err}
+}
+
+func classifyStatus(status int, message string) ErrorKind {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	case status == http.StatusPaymentRequired:
+		return ErrQuota
+	case status == http.StatusTooManyRequests:
+		if isQuotaMessage(message) {
+			return ErrQuota
+		}
+		return ErrRateLimit
+	case status >= 500:
+		return ErrServer
+	}
+	return ErrBadRequest
+}
+
+// isQuotaMessage spots 429s that mean "out of credits" rather than "slow down"
+func isQuotaMessage(message string) bool {
+	m := strings.ToLower(message)
+	for _, s := range []string{"insufficient_quota", "exceeded your current quota", "billing", "credit balance"} {
+		if strings.Contains(m, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// FallbackCooldown is how long a provider that failed is skipped for,
+// unless it asked for a different wait with Retry-After
+const FallbackCooldown = 5 * time.Minute
+
+// OnFallback, when set, is called each time a request moves on from a
+// failing provider to the next one in the chain
+var OnFallback func(from, to string, err error)
+
+// FallbackProvider tries each provider of a chain in order, moving on
+// when one is rate limited, down or not set up. Providers that failed
+// recently are tried last.
+type FallbackProvider struct {
+	members []Provider
+	health  *healthStore
+
+	mu     sync.Mutex
+	served string // Name of the provider that answered the last request
+}
+
+// NewFallbackProvider chains members in order of preference
+func NewFallbackProvider(members []Provider) *FallbackProvider {
+	return &FallbackProvider{members: members, health: openHealthStore()}
+}
+
+// Members returns the chain in configured order
+func (f *FallbackProvider) Members() []Provider {
+	return f.members
+}
+
+// Name returns the provider the next request will try first
+func (f *FallbackProvider) Name() string {
+	return f.first().Name()
+}
+
+func (f *FallbackProvider) Model() string {
+	return ModelOf(f.first())
+}
+
+// Unwrap returns the provider the next request will try first
+func (f *FallbackProvider) Unwrap() Provider {
+	return f.first()
+}
+
+// Served returns the name of the provider that answered the last
+// request, or "" before any request succeeded
+func (f *FallbackProvider) Served() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.served
+}
+
+func (f *FallbackProvider) IsConfigured() bool {
+	for _, p := range f.members {
+		if p.IsConfigured() {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FallbackProvider) Generate(prompt string) (string, error) {
+	return generate(f, prompt)
+}
+
+func (f *FallbackProvider) Chat(ctx context.Context, req Request) (*Response, error) {
+	return f.Stream(ctx, req, nil)
+}
+
+func (f *FallbackProvider) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	candidates := f.order()
+	if len(candidates) == 0 {
+		return nil, errors.New("no provider in the fallback chain is configured")
+	}
+
+	var errs []error
+	for i, p := range candidates {
+		// Once text has reached the caller the reply can't be taken back
+		started := false
+		var sink StreamFunc
+		if fn != nil {
+			sink = func(chunk string) error {
+				started = true
+				return fn(chunk)
+			}
+		}
+
+		var resp *Response
+		var err error
+		if sink != nil {
+			resp, err = ChatStream(ctx, p, req, sink)
+		} else {
+			resp, err = p.Chat(ctx, req)
+		}
+		if err == nil {
+			f.health.markUp(p.Name())
+			f.mu.Lock()
+			f.served = p.Name()
+			f.mu.Unlock()
+			resp.Provider = p.Name()
+			return resp, nil
+		}
+
+		errs = append(errs, err)
+		if started || ctx.Err() != nil || !shouldFallBack(err) {
+			return nil, err
+		}
+		f.health.markDown(p.Name(), err)
+		if i+1 < len(candidates) && OnFallback != nil {
+			OnFallback(p.Name(), candidates[i+1].Name(), err)
+		}
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, &FallbackError{Errs: errs}
+}
+
+// first returns the provider at the head of the current order
+func (f *FallbackProvider) first() Provider {
+	if candidates := f.order(); len(candidates) > 0 {
+		return candidates[0]
+	}
+	return f.members[0]
+}
+
+// order lists configured members, healthy ones first, each group in
+// chain order. Providers that failed recently are kept as a last resort.
+func (f *FallbackProvider) order() []Provider {
+	var healthy, down []Provider
+	for _, p := range f.members {
+		if !p.IsConfigured() {
+			continue
+		}
+		if f.health.isDown(p.Name()) {
+			down = append(down, p)
+		} else {
+			healthy = append(healthy, p)
+		}
+	}
+	return append(healthy, down...)
+}
+
+// shouldFallBack reports whether another provider might succeed where
+// this one failed: transient trouble, bad credentials or quota, a model
+// the provider doesn't have, or no cached or recorded answer. Other bad
+// requests stop the chain since every provider would reject them.
+func shouldFallBack(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable() || apiErr.Kind == ErrAuth || apiErr.Kind == ErrQuota || apiErr.ModelNotFound()
+	}
+	var fixtureErr *FixtureMissError
+	return errors.Is(err, ErrCacheMiss) || errors.As(err, &fixtureErr)
+}
+
+// FallbackError is returned when every provider in a chain failed
+type FallbackError struct {
+	Errs []error // One per provider, in the order tried
+}
+
+func (e *FallbackError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return "all providers failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes each provider's error to errors.Is and errors.As
+func (e *FallbackError) Unwrap() []error {
+	return e.Errs
+}
+
+// ============== Health ==============
+
+// healthStore remembers which providers failed recently, in
+// ~/.openskill/provider-health.json, so later invocations skip them
+type healthStore struct {
+	path string
+
+	mu   sync.Mutex
+	down map[string]healthEntry
+}
+
+type healthEntry struct {
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+func openHealthStore() *healthStore {
+	h := &healthStore{down: map[string]healthEntry{}}
+	dir, err := config.Dir()
+	if err != nil {
+		return h
+	}
+	h.path = filepath.Join(dir, "provider-health.json")
+	if data, err := os.ReadFile(h.path); err == nil {
+		json.Unmarshal(data, &h.down)
+	}
+	return h
+}
+
+func (h *healthStore) isDown(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e, ok := h.down[strings.ToLower(name)]
+	return ok && time.Now().Before(e.Until)
+}
+
+func (h *healthStore) markDown(name string, err error) {
+	wait := FallbackCooldown
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		wait = apiErr.RetryAfter
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.down[strings.ToLower(name)] = healthEntry{Until: time.Now().Add(wait), Reason: err.Error()}
+	h.save()
+}
+
+func (h *healthStore) markUp(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.ToLower(name)
+	if _, ok := h.down[key]; !ok {
+		return
+	}
+	delete(h.down, key)
+	h.save()
+}
+
+// save writes unexpired entries; failures only cost the memory of health
+func (h *healthStore) save() {
+	if h.path == "" {
+		return
+	}
+	now := time.Now()
+	for name, e := range h.down {
+		if now.After(e.Until) {
+			delete(h.down, name)
+		}
+	}
+	data, err := json.MarshalIndent(h.down, "", "  ")
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(h.path), 0755) == nil {
+		os.WriteFile(h.path, data, 0600)
+	}
+}
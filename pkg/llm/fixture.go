@@ -0,0 +1,168 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Record/replay makes AI-backed commands hermetic. With OPENSKILL_RECORD
+// set to a directory, every successful provider call is saved there as a
+// fixture; with OPENSKILL_REPLAY, calls are answered from those fixtures
+// and unmatched requests fail. The response cache is off in both modes.
+const (
+	RecordEnv = "OPENSKILL_RECORD"
+	ReplayEnv = "OPENSKILL_REPLAY"
+)
+
+// fixture is the file saved for each request. The request is stored in
+// readable form so fixtures can be reviewed and diffed.
+type fixture struct {
+	Key      string          `json:"key"`
+	Provider string          `json:"provider"`
+	Model    string          `json:"model,omitempty"`
+	Request  keyedRequest    `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+type fixtureResponse struct {
+	Content      string `json:"content"`
+	Model        string `json:"model,omitempty"`
+	FinishReason string `json:"finish_reason,omitempty"`
+	Usage        *Usage `json:"usage,omitempty"` // So replayed runs still count tokens
+}
+
+// FixtureKey identifies a request for record/replay. The model is part of
+// the key so runs against several models of one provider, such as bench,
+// keep apart.
+func FixtureKey(provider, model string, req Request) string {
+	return RequestKey(provider, model, req)
+}
+
+// FixtureMissError is returned in replay mode for a request with no fixture
+type FixtureMissError struct {
+	Provider string
+	Key      string
+	Dir      string
+}
+
+func (e *FixtureMissError) Error() string {
+	return fmt.Sprintf("no recorded %s response for this request in %s (fixture %s.json); record it with %s=%s", e.Provider, e.Dir, e.Key, RecordEnv, e.Dir)
+}
+
+// FixtureProvider records or replays the calls made to a provider
+type FixtureProvider struct {
+	inner  Provider
+	dir    string
+	replay bool
+}
+
+// NewRecorder saves each successful call to p as a fixture in dir
+func NewRecorder(p Provider, dir string) *FixtureProvider {
+	return &FixtureProvider{inner: p, dir: dir}
+}
+
+// NewReplayer answers calls from fixtures in dir without calling p
+func NewReplayer(p Provider, dir string) *FixtureProvider {
+	return &FixtureProvider{inner: p, dir: dir, replay: true}
+}
+
+func (f *FixtureProvider) Name() string {
+	return f.inner.Name()
+}
+
+func (f *FixtureProvider) Model() string {
+	return ModelOf(f.inner)
+}
+
+// Unwrap returns the provider being recorded or replayed
+func (f *FixtureProvider) Unwrap() Provider {
+	return f.inner
+}
+
+// IsConfigured is always true when replaying, which needs no credentials
+func (f *FixtureProvider) IsConfigured() bool {
+	return f.replay || f.inner.IsConfigured()
+}
+
+func (f *FixtureProvider) Generate(prompt string) (string, error) {
+	return generate(f, prompt)
+}
+
+func (f *FixtureProvider) Chat(ctx context.Context, req Request) (*Response, error) {
+	return f.Stream(ctx, req, nil)
+}
+
+// Stream replays a fixture as a single chunk, or passes the call through
+// and records the result
+func (f *FixtureProvider) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	key := FixtureKey(f.Name(), f.Model(), req)
+	path := filepath.Join(f.dir, key+".json")
+
+	if f.replay {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, &FixtureMissError{Provider: f.Name(), Key: key, Dir: f.dir}
+		}
+		if err != nil {
+			return nil, err
+		}
+		var fx fixture
+		if err := json.Unmarshal(data, &fx); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+		}
+		resp := &Response{Content: fx.Response.Content, Model: fx.Response.Model, FinishReason: fx.Response.FinishReason, Cached: true}
+		if fx.Response.Usage != nil {
+			resp.Usage = *fx.Response.Usage
+		}
+		if fn != nil {
+			if err := fn(resp.Content); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+
+	var resp *Response
+	var err error
+	if fn != nil {
+		resp, err = ChatStream(ctx, f.inner, req, fn)
+	} else {
+		resp, err = f.inner.Chat(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(fixture{
+		Key:      key,
+		Provider: f.Name(),
+		Model:    f.Model(),
+		Request:  normalizedRequest(f.Name(), f.Model(), req),
+		Response: fixtureResponse{Content: resp.Content, Model: resp.Model, FinishReason: resp.FinishReason, Usage: &resp.Usage},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to record fixture: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to record fixture: %w", err)
+	}
+	return resp, nil
+}
+
+// withFixtures wraps p for OPENSKILL_RECORD / OPENSKILL_REPLAY. ok is
+// false when neither is set.
+func withFixtures(p Provider) (Provider, bool) {
+	if dir := os.Getenv(ReplayEnv); dir != "" {
+		return NewReplayer(p, dir), true
+	}
+	if dir := os.Getenv(RecordEnv); dir != "" {
+		return NewRecorder(p, dir), true
+	}
+	return p, false
+}
@@ -0,0 +1,70 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeProvider answers every request with a fixed reply
+type fakeProvider struct {
+	model string
+	reply Response
+	calls int
+}
+
+func (p *fakeProvider) Name() string       { return "Fake" }
+func (p *fakeProvider) Model() string      { return p.model }
+func (p *fakeProvider) IsConfigured() bool { return true }
+
+func (p *fakeProvider) Generate(prompt string) (string, error) { return generate(p, prompt) }
+
+func (p *fakeProvider) Chat(ctx context.Context, req Request) (*Response, error) {
+	p.calls++
+	resp := p.reply
+	return &resp, nil
+}
+
+func TestFixtureRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	req := PromptRequest("hi")
+	live := &fakeProvider{model: "small", reply: Response{Content: "hello", Model: "small-001", FinishReason: "stop", Usage: Usage{PromptTokens: 3, CompletionTokens: 1}}}
+	if _, err := NewRecorder(live, dir).Chat(context.Background(), req); err != nil {
+		t.Fatalf("record error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		model string
+		req   Request
+		miss  bool
+	}{
+		{name: "same model and request", model: "small", req: req},
+		{name: "another model of the same provider", model: "large", req: req, miss: true},
+		{name: "another request", model: "small", req: PromptRequest("bye"), miss: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &fakeProvider{model: tt.model}
+			resp, err := NewReplayer(inner, dir).Chat(context.Background(), tt.req)
+			if inner.calls != 0 {
+				t.Errorf("replay called the provider")
+			}
+			if tt.miss {
+				var miss *FixtureMissError
+				if !errors.As(err, &miss) {
+					t.Fatalf("error = %v, want a fixture miss", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("replay error = %v", err)
+			}
+			want := live.reply
+			want.Cached = true
+			if *resp != want {
+				t.Errorf("replayed %+v, want %+v", *resp, want)
+			}
+		})
+	}
+}
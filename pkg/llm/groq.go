@@ -2,9 +2,11 @@ package llm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"openskill/pkg/config"
 )
@@ -16,6 +18,14 @@ type Client struct {
 	endpoint string
 }
 
+func init() {
+	RegisterProvider(ProviderInfo{
+		Name:        string(ProviderGroq),
+		Description: "Groq cloud (default)",
+		New:         func() Provider { return NewClient() },
+	})
+}
+
 // NewClient creates a new Groq client
 func NewClient() *Client {
 	return &Client{
@@ -29,13 +39,35 @@ func (c *Client) Name() string {
 	return "Groq"
 }
 
+func (c *Client) Model() string {
+	return c.model
+}
+
 func (c *Client) IsConfigured() bool {
 	return c.apiKey != ""
 }
 
+// OpenAI-compatible chat completion types, shared by Groq and OpenAI
 type chatRequest struct {
-	Model    string    `json:"model"`
-	Messages []message `json:"messages"`
+	Model          string          `json:"model,omitempty"`
+	Messages       []message       `json:"messages"`
+	Temperature    *float64        `json:"temperature,omitempty"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	Stop           []string        `json:"stop,omitempty"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+}
+
+// streamOptions asks OpenAI to send token usage in the last stream chunk
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// responseFormat selects JSON mode; the schema itself goes in the prompt
+// since Groq models don't all support json_schema
+type responseFormat struct {
+	Type string `json:"type"`
 }
 
 type message struct {
@@ -44,44 +76,214 @@ type message struct {
 }
 
 type chatResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message struct {
 			Content string `json:"content"`
 		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
+	Usage *Usage `json:"usage,omitempty"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
 }
 
 func (c *Client) Generate(prompt string) (string, error) {
-	req := chatRequest{
-		Model: c.model,
-		Messages: []message{
-			{Role: "user", Content: prompt},
-		},
+	return generate(c, prompt)
+}
+
+func (c *Client) Chat(ctx context.Context, req Request) (*Response, error) {
+	return chatCompletion(ctx, c.api(), req)
+}
+
+func (c *Client) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	return streamChatCompletion(ctx, c.api(), req, fn)
+}
+
+func (c *Client) api() chatAPI {
+	return chatAPI{name: c.Name(), url: c.endpoint, apiKey: c.apiKey, model: c.model}
+}
+
+// chatAPI describes an OpenAI-compatible chat completions endpoint
+type chatAPI struct {
+	name    string
+	url     string
+	apiKey  string // Optional for self-hosted servers
+	model   string
+	headers map[string]string // Extra headers, e.g. for OpenRouter
+	azure   bool              // Send the key as api-key instead of a bearer token
+
+	streamUsage bool // Send stream_options.include_usage; not every server accepts it
+}
+
+// newChatRequest converts a Request to the OpenAI chat completions format
+func newChatRequest(model string, req Request) chatRequest {
+	out := chatRequest{
+		Model:       model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stop:        req.Stop,
+	}
+	if req.Format != nil {
+		out.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+	if req.System != "" {
+		out.Messages = append(out.Messages, message{Role: RoleSystem, Content: req.System})
 	}
+	for _, m := range req.Messages {
+		out.Messages = append(out.Messages, message{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
 
-	body, _ := json.Marshal(req)
-	httpReq, _ := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(body))
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+// postChat sends an OpenAI-compatible chat completions request and turns
+// non-200 responses into errors. The caller closes the response body.
+func postChat(ctx context.Context, api chatAPI, payload chatRequest) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", api.url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	switch {
+	case api.apiKey == "":
+	case api.azure:
+		httpReq.Header.Set("api-key", api.apiKey)
+	default:
+		httpReq.Header.Set("Authorization", "Bearer "+api.apiKey)
+	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range api.headers {
+		httpReq.Header.Set(k, v)
+	}
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	name := api.name
+	resp, err := HTTPClient.Do(httpReq)
 	if err != nil {
-		return "", err
+		return nil, networkError(ctx, name, err)
+	}
+	if resp.StatusCode == 200 {
+		return resp, nil
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("Groq API error: %s", resp.Status)
+	var result chatResponse
+	message := ""
+	if json.NewDecoder(resp.Body).Decode(&result) == nil && result.Error != nil {
+		message = result.Error.Message
+	}
+	return nil, newAPIError(name, resp, message)
+}
+
+// chatCompletion calls an OpenAI-compatible chat completions endpoint
+func chatCompletion(ctx context.Context, api chatAPI, req Request) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	resp, err := postChat(ctx, api, newChatRequest(api.model, req))
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var result chatResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if len(result.Choices) == 0 {
-		return "", fmt.Errorf("no response from Groq")
+		return nil, fmt.Errorf("no response from %s", api.name)
+	}
+
+	out := &Response{
+		Content:      result.Choices[0].Message.Content,
+		Model:        result.Model,
+		FinishReason: result.Choices[0].FinishReason,
+	}
+	if result.Usage != nil {
+		out.Usage = *result.Usage
+	}
+	return out, nil
+}
+
+// chatStreamChunk is one server-sent event from a streaming chat completion
+type chatStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *Usage `json:"usage,omitempty"`
+	XGroq *struct {
+		Usage *Usage `json:"usage,omitempty"`
+	} `json:"x_groq,omitempty"` // Groq reports stream usage here
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// streamChatCompletion streams an OpenAI-compatible chat completion,
+// calling fn with each content delta
+func streamChatCompletion(ctx context.Context, api chatAPI, req Request, fn StreamFunc) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	payload := newChatRequest(api.model, req)
+	payload.Stream = true
+	if api.streamUsage {
+		payload.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+	resp, err := postChat(ctx, api, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	name := api.name
+	out := &Response{Model: api.model}
+	var content strings.Builder
+	err = readSSE(resp.Body, func(event, data string) error {
+		if data == "[DONE]" {
+			return errStreamDone
+		}
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("invalid %s stream event: %w", name, err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("%s API error: %s", name, chunk.Error.Message)
+		}
+		if chunk.Model != "" {
+			out.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			out.Usage = *chunk.Usage
+		} else if chunk.XGroq != nil && chunk.XGroq.Usage != nil {
+			out.Usage = *chunk.XGroq.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil {
+				out.FinishReason = *choice.FinishReason
+			}
+			if choice.Delta.Content == "" {
+				continue
+			}
+			content.WriteString(choice.Delta.Content)
+			if err := fn(choice.Delta.Content); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result.Choices[0].Message.Content, nil
+	out.Content = content.String()
+	return out, nil
 }
@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"openskill/pkg/usage"
+)
+
+// MeteredProvider records token usage and cost of every call in the
+// usage ledger and enforces the --budget cap
+type MeteredProvider struct {
+	inner Provider
+}
+
+// NewMeteredProvider wraps p with usage accounting
+func NewMeteredProvider(p Provider) *MeteredProvider {
+	return &MeteredProvider{inner: p}
+}
+
+func (m *MeteredProvider) Name() string {
+	return m.inner.Name()
+}
+
+func (m *MeteredProvider) Model() string {
+	return ModelOf(m.inner)
+}
+
+// Unwrap returns the provider being metered
+func (m *MeteredProvider) Unwrap() Provider {
+	return m.inner
+}
+
+func (m *MeteredProvider) IsConfigured() bool {
+	return m.inner.IsConfigured()
+}
+
+func (m *MeteredProvider) Generate(prompt string) (string, error) {
+	return generate(m, prompt)
+}
+
+func (m *MeteredProvider) Chat(ctx context.Context, req Request) (*Response, error) {
+	return m.Stream(ctx, req, nil)
+}
+
+func (m *MeteredProvider) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	if err := usage.CheckBudget(); err != nil {
+		return nil, err
+	}
+
+	var resp *Response
+	var err error
+	if fn != nil {
+		resp, err = ChatStream(ctx, m.inner, req, fn)
+	} else {
+		resp, err = m.inner.Chat(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.record(ctx, resp)
+	return resp, nil
+}
+
+// record charges the call to the budget and appends it to the ledger
+func (m *MeteredProvider) record(ctx context.Context, resp *Response) {
+	model := m.Model()
+	if model == "" {
+		model = resp.Model
+	}
+	command, skill := usage.Labels(ctx)
+	entry := usage.Entry{
+		Time:             time.Now(),
+		Command:          command,
+		Skill:            skill,
+		Provider:         strings.ToLower(m.Name()),
+		Model:            model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		Cached:           resp.Cached,
+		Priced:           true,
+	}
+
+	if !resp.Cached {
+		price, ok := usage.PriceFor(entry.Provider, model)
+		if !ok && resp.Model != "" {
+			// The API may name a dated snapshot the table knows
+			price, ok = usage.PriceFor(entry.Provider, resp.Model)
+		}
+		entry.Priced = ok
+		entry.Cost = usage.Cost(price, entry.PromptTokens, entry.CompletionTokens)
+		usage.Charge(entry.Cost)
+	}
+
+	// Accounting must never break a command
+	usage.Append(entry)
+}
@@ -0,0 +1,257 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// ModelLister is implemented by providers that can list the models they serve
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]string, error)
+}
+
+// ModelsTTL is how long a provider's model list is reused before it is
+// fetched again
+const ModelsTTL = 24 * time.Hour
+
+// ListModels asks p, or the provider it wraps, for its models, sorted
+func ListModels(ctx context.Context, p Provider) ([]string, error) {
+	lister, ok := Unwrap(p).(ModelLister)
+	if !ok {
+		return nil, fmt.Errorf("%s can't list its models", p.Name())
+	}
+	models, err := lister.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(models)
+	return models, nil
+}
+
+// ModelList is a provider's models as last fetched
+type ModelList struct {
+	Models    []string  `json:"models"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Fresh reports whether the list is recent enough to reuse
+func (l ModelList) Fresh() bool {
+	return time.Since(l.FetchedAt) < ModelsTTL
+}
+
+// Contains reports whether model is in the list. An untagged name
+// matches its :latest tag, as in Ollama.
+func (l ModelList) Contains(model string) bool {
+	for _, m := range l.Models {
+		if m == model || m == model+":latest" {
+			return true
+		}
+	}
+	return false
+}
+
+// modelsCachePath is ~/.openskill/models.json
+func modelsCachePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "models.json"), nil
+}
+
+func readModelsCache() map[string]ModelList {
+	lists := map[string]ModelList{}
+	path, err := modelsCachePath()
+	if err != nil {
+		return lists
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &lists)
+	}
+	return lists
+}
+
+// CachedModels returns the stored model list of a registered provider
+func CachedModels(provider string) (ModelList, bool) {
+	list, ok := readModelsCache()[strings.ToLower(provider)]
+	return list, ok
+}
+
+// FetchModels lists a registered provider's models and stores the result
+func FetchModels(ctx context.Context, provider string) (ModelList, error) {
+	info, ok := LookupProvider(provider)
+	if !ok {
+		return ModelList{}, fmt.Errorf("unknown provider: %s", provider)
+	}
+	models, err := ListModels(ctx, info.New())
+	if err != nil {
+		return ModelList{}, err
+	}
+
+	list := ModelList{Models: models, FetchedAt: time.Now()}
+	lists := readModelsCache()
+	lists[strings.ToLower(provider)] = list
+	if path, err := modelsCachePath(); err == nil {
+		if data, err := json.MarshalIndent(lists, "", "  "); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0755) == nil {
+				os.WriteFile(path, data, 0600)
+			}
+		}
+	}
+	return list, nil
+}
+
+// Models returns a provider's models from the cache while it is fresh,
+// fetching them otherwise or when refresh is set
+func Models(ctx context.Context, provider string, refresh bool) (ModelList, bool, error) {
+	if !refresh {
+		if list, ok := CachedModels(provider); ok && list.Fresh() {
+			return list, true, nil
+		}
+	}
+	list, err := FetchModels(ctx, provider)
+	return list, false, err
+}
+
+// ============== Provider listings ==============
+
+// getJSON fetches endpoint into out, turning non-200 responses into errors
+func getJSON(ctx context.Context, name, endpoint string, headers map[string]string, out interface{}) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := HTTPClient.Do(httpReq)
+	if err != nil {
+		return networkError(ctx, name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var result struct {
+			Error json.RawMessage `json:"error"`
+		}
+		message := ""
+		if json.NewDecoder(resp.Body).Decode(&result) == nil && len(result.Error) > 0 {
+			var detail struct {
+				Message string `json:"message"`
+			}
+			if json.Unmarshal(result.Error, &detail) == nil && detail.Message != "" {
+				message = detail.Message
+			} else {
+				json.Unmarshal(result.Error, &message) // Ollama sends a plain string
+			}
+		}
+		return newAPIError(name, resp, message)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid model list from %s: %w", name, err)
+	}
+	return nil
+}
+
+// listChatModels lists models from an OpenAI-compatible /models endpoint
+// next to the chat completions URL
+func listChatModels(ctx context.Context, api chatAPI) ([]string, error) {
+	if api.azure {
+		return nil, fmt.Errorf("%s routes by deployment and can't list models", api.name)
+	}
+	headers := map[string]string{}
+	if api.apiKey != "" {
+		headers["Authorization"] = "Bearer " + api.apiKey
+	}
+	for k, v := range api.headers {
+		headers[k] = v
+	}
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	modelsURL := strings.TrimSuffix(api.url, "/chat/completions") + "/models"
+	if err := getJSON(ctx, api.name, modelsURL, headers, &result); err != nil {
+		return nil, err
+	}
+	var models []string
+	for _, m := range result.Data {
+		models = append(models, m.ID)
+	}
+	return models, nil
+}
+
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	return listChatModels(ctx, c.api())
+}
+
+func (c *OpenAIClient) ListModels(ctx context.Context) ([]string, error) {
+	return listChatModels(ctx, c.api())
+}
+
+func (c *CompatibleClient) ListModels(ctx context.Context) ([]string, error) {
+	return listChatModels(ctx, c.api)
+}
+
+// modelsURL is the Models API next to the Messages endpoint
+func (c *AnthropicClient) modelsURL() string {
+	return strings.TrimSuffix(c.endpoint, "/messages") + "/models?limit=1000"
+}
+
+func (c *AnthropicClient) ListModels(ctx context.Context) ([]string, error) {
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	headers := map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+	if err := getJSON(ctx, c.Name(), c.modelsURL(), headers, &result); err != nil {
+		return nil, err
+	}
+	var models []string
+	for _, m := range result.Data {
+		models = append(models, m.ID)
+	}
+	return models, nil
+}
+
+// ListModels returns the models pulled into the local Ollama server
+func (c *OllamaClient) ListModels(ctx context.Context) ([]string, error) {
+	base := strings.TrimSuffix(c.endpoint, "/api/chat")
+	if u, err := url.Parse(c.endpoint); err == nil && base == c.endpoint {
+		base = u.Scheme + "://" + u.Host
+	}
+
+	var result struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := getJSON(ctx, c.Name(), base+"/api/tags", nil, &result); err != nil {
+		return nil, err
+	}
+	var models []string
+	for _, m := range result.Models {
+		models = append(models, m.Name)
+	}
+	return models, nil
+}
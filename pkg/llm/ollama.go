@@ -2,9 +2,11 @@ package llm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"openskill/pkg/config"
 )
@@ -15,6 +17,14 @@ type OllamaClient struct {
 	endpoint string
 }
 
+func init() {
+	RegisterProvider(ProviderInfo{
+		Name:        string(ProviderOllama),
+		Description: "Local models via Ollama",
+		New:         func() Provider { return NewOllamaClient() },
+	})
+}
+
 // NewOllamaClient creates a new Ollama client
 func NewOllamaClient() *OllamaClient {
 	endpoint := config.GetOllamaEndpoint()
@@ -31,6 +41,10 @@ func (c *OllamaClient) Name() string {
 	return "Ollama"
 }
 
+func (c *OllamaClient) Model() string {
+	return c.model
+}
+
 func (c *OllamaClient) IsConfigured() bool {
 	// Ollama doesn't need an API key, just check if endpoint is reachable
 	// For now, always return true and let it fail on actual request
@@ -42,6 +56,8 @@ type ollamaRequest struct {
 	Model    string          `json:"model"`
 	Messages []ollamaMessage `json:"messages"`
 	Stream   bool            `json:"stream"`
+	Format   string          `json:"format,omitempty"`
+	Options  *ollamaOptions  `json:"options,omitempty"`
 }
 
 type ollamaMessage struct {
@@ -49,50 +65,149 @@ type ollamaMessage struct {
 	Content string `json:"content"`
 }
 
+type ollamaOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
 type ollamaResponse struct {
+	Model   string `json:"model"`
 	Message struct {
 		Content string `json:"content"`
 	} `json:"message"`
-	Error string `json:"error,omitempty"`
+	Done            bool   `json:"done"`
+	DoneReason      string `json:"done_reason,omitempty"`
+	PromptEvalCount int    `json:"prompt_eval_count,omitempty"` // Sent on the final message
+	EvalCount       int    `json:"eval_count,omitempty"`
+	Error           string `json:"error,omitempty"`
 }
 
 func (c *OllamaClient) Generate(prompt string) (string, error) {
-	req := ollamaRequest{
-		Model: c.model,
-		Messages: []ollamaMessage{
-			{Role: "user", Content: prompt},
-		},
-		Stream: false,
+	return generate(c, prompt)
+}
+
+// newOllamaRequest converts a Request to the /api/chat format
+func newOllamaRequest(model string, req Request, stream bool) ollamaRequest {
+	out := ollamaRequest{Model: model, Stream: stream}
+	if req.Format != nil {
+		out.Format = "json"
+	}
+	if req.System != "" {
+		out.Messages = append(out.Messages, ollamaMessage{Role: RoleSystem, Content: req.System})
+	}
+	for _, m := range req.Messages {
+		out.Messages = append(out.Messages, ollamaMessage{Role: m.Role, Content: m.Content})
+	}
+	if req.Temperature != nil || req.MaxTokens > 0 || len(req.Stop) > 0 {
+		out.Options = &ollamaOptions{Temperature: req.Temperature, NumPredict: req.MaxTokens, Stop: req.Stop}
 	}
+	return out
+}
 
-	body, _ := json.Marshal(req)
-	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(body))
+// post sends an /api/chat request and turns non-200 responses into
+// errors. The caller closes the response body.
+func (c *OllamaClient) post(ctx context.Context, payload ollamaRequest) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(body))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := HTTPClient.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("Ollama not reachable at %s: %w", c.endpoint, err)
+		return nil, networkError(ctx, c.Name(), err)
+	}
+	if resp.StatusCode == 200 {
+		return resp, nil
 	}
 	defer resp.Body.Close()
 
 	var result ollamaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+	message := ""
+	if json.NewDecoder(resp.Body).Decode(&result) == nil {
+		message = result.Error
 	}
+	apiErr := newAPIError(c.Name(), resp, message)
+	if resp.StatusCode == http.StatusNotFound {
+		// Ollama answers 404 when the model hasn't been pulled
+		apiErr.Kind = ErrBadRequest
+	}
+	return nil, apiErr
+}
 
-	if resp.StatusCode != 200 {
-		if result.Error != "" {
-			return "", fmt.Errorf("Ollama error: %s", result.Error)
-		}
-		return "", fmt.Errorf("Ollama API error: %s", resp.Status)
+func (c *OllamaClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.post(ctx, newOllamaRequest(c.model, req, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
 	}
 
 	if result.Message.Content == "" {
-		return "", fmt.Errorf("no response from Ollama")
+		return nil, fmt.Errorf("no response from Ollama")
+	}
+
+	return &Response{
+		Content:      result.Message.Content,
+		Model:        result.Model,
+		FinishReason: result.DoneReason,
+		Usage:        Usage{PromptTokens: result.PromptEvalCount, CompletionTokens: result.EvalCount},
+	}, nil
+}
+
+// Stream reads Ollama's newline-delimited JSON stream
+func (c *OllamaClient) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.post(ctx, newOllamaRequest(c.model, req, true))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out := &Response{Model: c.model}
+	var content strings.Builder
+	err = readNDJSON(resp.Body, func(line []byte) error {
+		var chunk ollamaResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return fmt.Errorf("invalid Ollama stream line: %w", err)
+		}
+		if chunk.Error != "" {
+			return fmt.Errorf("Ollama error: %s", chunk.Error)
+		}
+		if chunk.Model != "" {
+			out.Model = chunk.Model
+		}
+		if text := chunk.Message.Content; text != "" {
+			content.WriteString(text)
+			if err := fn(text); err != nil {
+				return err
+			}
+		}
+		if chunk.Done {
+			out.FinishReason = chunk.DoneReason
+			out.Usage = Usage{PromptTokens: chunk.PromptEvalCount, CompletionTokens: chunk.EvalCount}
+			return errStreamDone
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result.Message.Content, nil
+	out.Content = content.String()
+	return out, nil
 }
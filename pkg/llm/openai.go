@@ -1,10 +1,7 @@
 package llm
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"context"
 
 	"openskill/pkg/config"
 )
@@ -16,6 +13,14 @@ type OpenAIClient struct {
 	endpoint string
 }
 
+func init() {
+	RegisterProvider(ProviderInfo{
+		Name:        string(ProviderOpenAI),
+		Description: "OpenAI API",
+		New:         func() Provider { return NewOpenAIClient() },
+	})
+}
+
 // NewOpenAIClient creates a new OpenAI client
 func NewOpenAIClient() *OpenAIClient {
 	return &OpenAIClient{
@@ -29,41 +34,26 @@ func (c *OpenAIClient) Name() string {
 	return "OpenAI"
 }
 
+func (c *OpenAIClient) Model() string {
+	return c.model
+}
+
 func (c *OpenAIClient) IsConfigured() bool {
 	return c.apiKey != ""
 }
 
 func (c *OpenAIClient) Generate(prompt string) (string, error) {
-	req := chatRequest{
-		Model: c.model,
-		Messages: []message{
-			{Role: "user", Content: prompt},
-		},
-	}
-
-	body, _ := json.Marshal(req)
-	httpReq, _ := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(body))
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("OpenAI API error: %s", resp.Status)
-	}
+	return generate(c, prompt)
+}
 
-	var result chatResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
+func (c *OpenAIClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	return chatCompletion(ctx, c.api(), req)
+}
 
-	if len(result.Choices) == 0 {
-		return "", fmt.Errorf("no response from OpenAI")
-	}
+func (c *OpenAIClient) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	return streamChatCompletion(ctx, c.api(), req, fn)
+}
 
-	return result.Choices[0].Message.Content, nil
+func (c *OpenAIClient) api() chatAPI {
+	return chatAPI{name: c.Name(), url: c.endpoint, apiKey: c.apiKey, model: c.model, streamUsage: true}
 }
@@ -0,0 +1,24 @@
+package llm
+
+// Payload returns the request body p would send for req, showing where
+// each provider puts the system prompt and turns: Anthropic's top-level
+// system field, a leading system message for OpenAI-compatible APIs and
+// Ollama, or the plugin protocol's system field. Providers whose wire
+// format isn't known get req itself.
+func Payload(p Provider, req Request) interface{} {
+	switch c := Unwrap(p).(type) {
+	case *AnthropicClient:
+		return newAnthropicRequest(c.model, req)
+	case *OllamaClient:
+		return newOllamaRequest(c.model, req, false)
+	case *Client:
+		return newChatRequest(c.model, req)
+	case *OpenAIClient:
+		return newChatRequest(c.model, req)
+	case *CompatibleClient:
+		return newChatRequest(c.api.model, req)
+	case *PluginClient:
+		return newPluginRequest(c.model, req, false)
+	}
+	return req
+}
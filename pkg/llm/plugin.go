@@ -0,0 +1,320 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"openskill/pkg/config"
+)
+
+// Provider plugins are executables (openskill-provider-<name> by default)
+// configured under plugins in the config file. Each call starts the
+// plugin, writes one JSON request line to its stdin and closes it, then
+// reads newline-delimited JSON messages from its stdout:
+//
+//	request:  {"version":1,"type":"generate","model":"...","stream":true,
+//	           "system":"...","messages":[{"role":"user","content":"..."}],
+//	           "temperature":0.2,"max_tokens":0,"stop":[],"format":{...}}
+//	          {"version":1,"type":"health"}
+//	messages: {"type":"chunk","content":"partial text"}
+//	          {"type":"done","content":"full text","model":"...","finish_reason":"stop",
+//	           "usage":{"prompt_tokens":12,"completion_tokens":40}}
+//	          {"type":"error","error":{"kind":"rate_limit","message":"..."}}
+//	          {"type":"health","ok":true,"message":"gateway reachable"}
+//
+// A generate call ends with done or error; content on done may be omitted
+// when the reply was sent as chunks. Anything on stderr is only used to
+// explain a failure.
+
+// PluginProtocolVersion is sent with every plugin request
+const PluginProtocolVersion = 1
+
+// PluginPrefix is prepended to a plugin's name to find its executable
+const PluginPrefix = "openskill-provider-"
+
+type pluginRequest struct {
+	Version     int       `json:"version"`
+	Type        string    `json:"type"` // "generate" or "health"
+	Model       string    `json:"model,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+	System      string    `json:"system,omitempty"`
+	Messages    []Message `json:"messages,omitempty"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	Format      *Format   `json:"format,omitempty"`
+}
+
+type pluginMessage struct {
+	Type         string `json:"type"` // "chunk", "done", "error" or "health"
+	Content      string `json:"content,omitempty"`
+	Model        string `json:"model,omitempty"`
+	FinishReason string `json:"finish_reason,omitempty"`
+	Usage        *Usage `json:"usage,omitempty"`
+	OK           bool   `json:"ok,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Error        *struct {
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func init() {
+	RegisterProviderSource(pluginProviders)
+}
+
+// pluginProviders lists the providers under plugins in the config
+func pluginProviders() []ProviderInfo {
+	var infos []ProviderInfo
+	for _, name := range config.GetPluginNames() {
+		name := name
+		p, _ := config.GetPlugin(name)
+		infos = append(infos, ProviderInfo{
+			Name:        name,
+			Description: "Plugin: " + PluginCommand(name, p),
+			New: func() Provider {
+				p, _ := config.GetPlugin(name)
+				return NewPluginClient(name, p)
+			},
+		})
+	}
+	return infos
+}
+
+// PluginClient implements the Provider interface by running an external
+// provider plugin
+type PluginClient struct {
+	name  string
+	cfg   config.Plugin
+	model string
+}
+
+// NewPluginClient creates a client for a configured plugin
+func NewPluginClient(name string, cfg config.Plugin) *PluginClient {
+	return &PluginClient{name: name, cfg: cfg, model: config.GetProviderModel(name)}
+}
+
+func (c *PluginClient) Name() string {
+	return c.name
+}
+
+func (c *PluginClient) Model() string {
+	return c.model
+}
+
+// IsConfigured reports whether the plugin executable can be found
+func (c *PluginClient) IsConfigured() bool {
+	_, err := exec.LookPath(PluginCommand(c.name, c.cfg))
+	return err == nil
+}
+
+func (c *PluginClient) Generate(prompt string) (string, error) {
+	return generate(c, prompt)
+}
+
+func (c *PluginClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	return c.generate(ctx, req, nil)
+}
+
+func (c *PluginClient) Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	return c.generate(ctx, req, fn)
+}
+
+// Health asks the plugin whether it can serve requests
+func (c *PluginClient) Health(ctx context.Context) error {
+	healthy := false
+	err := c.run(ctx, pluginRequest{Version: PluginProtocolVersion, Type: "health"}, func(msg pluginMessage) error {
+		if msg.Type != "health" {
+			return nil
+		}
+		if !msg.OK {
+			return &APIError{Provider: c.name, Kind: ErrServer, Message: msg.Message}
+		}
+		healthy = true
+		return errStreamDone
+	})
+	if err != nil {
+		return err
+	}
+	if !healthy {
+		return &APIError{Provider: c.name, Kind: ErrServer, Message: "plugin did not answer the health check"}
+	}
+	return nil
+}
+
+// newPluginRequest converts a Request to a plugin generate request
+func newPluginRequest(model string, req Request, stream bool) pluginRequest {
+	return pluginRequest{
+		Version:     PluginProtocolVersion,
+		Type:        "generate",
+		Model:       model,
+		Stream:      stream,
+		System:      req.System,
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stop:        req.Stop,
+		Format:      req.Format,
+	}
+}
+
+func (c *PluginClient) generate(ctx context.Context, req Request, fn StreamFunc) (*Response, error) {
+	preq := newPluginRequest(c.model, req, fn != nil)
+
+	out := &Response{Model: c.model}
+	var content strings.Builder
+	done := false
+	err := c.run(ctx, preq, func(msg pluginMessage) error {
+		switch msg.Type {
+		case "chunk":
+			if msg.Content == "" {
+				return nil
+			}
+			content.WriteString(msg.Content)
+			if fn != nil {
+				return fn(msg.Content)
+			}
+		case "done":
+			if msg.Content != "" {
+				content.Reset()
+				content.WriteString(msg.Content)
+			}
+			if msg.Model != "" {
+				out.Model = msg.Model
+			}
+			out.FinishReason = msg.FinishReason
+			if msg.Usage != nil {
+				out.Usage = *msg.Usage
+			}
+			done = true
+			return errStreamDone
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		return nil, &APIError{Provider: c.name, Kind: ErrServer, Message: "plugin exited without a done message"}
+	}
+	if content.Len() == 0 {
+		return nil, fmt.Errorf("no response from %s", c.name)
+	}
+
+	out.Content = content.String()
+	return out, nil
+}
+
+// run starts the plugin, sends req and passes each message to fn until
+// fn returns errStreamDone or the plugin exits. Error messages from the
+// plugin become APIErrors.
+func (c *PluginClient) run(ctx context.Context, req pluginRequest, fn func(pluginMessage) error) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	line, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	command := PluginCommand(c.name, c.cfg)
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return &APIError{Provider: c.name, Kind: ErrNetwork, Err: fmt.Errorf("plugin %s not found: %w", command, err)}
+	}
+
+	cmd := exec.CommandContext(ctx, path, c.cfg.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range c.cfg.Env {
+		cmd.Env = append(cmd.Env, k+"="+os.ExpandEnv(v))
+	}
+	cmd.Stdin = bytes.NewReader(append(line, '\n'))
+	var stderr tailBuffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return &APIError{Provider: c.name, Kind: ErrNetwork, Err: err}
+	}
+
+	readErr := readNDJSON(stdout, func(data []byte) error {
+		var msg pluginMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("invalid message from %s plugin: %w", c.name, err)
+		}
+		if msg.Type == "error" {
+			apiErr := &APIError{Provider: c.name, Kind: ErrServer}
+			if msg.Error != nil {
+				apiErr.Kind = parseErrorKind(msg.Error.Kind)
+				apiErr.Message = msg.Error.Message
+			}
+			return apiErr
+		}
+		return fn(msg)
+	})
+	// Let the plugin finish writing so it can exit
+	io.Copy(io.Discard, stdout)
+	waitErr := cmd.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case readErr != nil:
+		return readErr
+	case waitErr != nil:
+		// The last line of stderr usually says what went wrong
+		msg := strings.TrimSpace(stderr.String())
+		if i := strings.LastIndex(msg, "\n"); i >= 0 {
+			msg = strings.TrimSpace(msg[i+1:])
+		}
+		if msg == "" {
+			msg = waitErr.Error()
+		}
+		return &APIError{Provider: c.name, Kind: ErrServer, Message: "plugin failed: " + msg}
+	}
+	return nil
+}
+
+// PluginCommand returns the configured command or openskill-provider-<name>
+func PluginCommand(name string, cfg config.Plugin) string {
+	if cfg.Command != "" {
+		return os.ExpandEnv(cfg.Command)
+	}
+	return PluginPrefix + name
+}
+
+// parseErrorKind maps a plugin's error kind onto ErrorKind
+func parseErrorKind(kind string) ErrorKind {
+	switch k := ErrorKind(kind); k {
+	case ErrAuth, ErrRateLimit, ErrQuota, ErrBadRequest, ErrServer, ErrNetwork:
+		return k
+	}
+	return ErrServer
+}
+
+// tailBuffer keeps the last few KB written to it
+type tailBuffer struct {
+	buf []byte
+}
+
+const tailBufferSize = 4096
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > tailBufferSize {
+		b.buf = b.buf[len(b.buf)-tailBufferSize:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.buf)
+}
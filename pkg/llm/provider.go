@@ -1,12 +1,46 @@
 package llm
 
+import (
+	"context"
+
+	"openskill/pkg/config"
+)
+
 // Provider represents an LLM provider interface
 type Provider interface {
 	Name() string
+
+	// Chat sends a conversation and returns the assistant's reply. The
+	// request is abandoned when ctx is cancelled.
+	Chat(ctx context.Context, req Request) (*Response, error)
+
+	// Generate sends a single user prompt. It is a shim over Chat kept
+	// for existing callers and cannot be cancelled.
 	Generate(prompt string) (string, error)
+
 	IsConfigured() bool
 }
 
+// ModelReporter is implemented by providers that know which model they
+// send requests to
+type ModelReporter interface {
+	Model() string
+}
+
+// ModelOf returns the model p sends requests to, or "" if it doesn't say
+func ModelOf(p Provider) string {
+	if m, ok := p.(ModelReporter); ok {
+		return m.Model()
+	}
+	return ""
+}
+
+// HealthChecker is implemented by providers that can report whether they
+// are able to serve requests without generating anything
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
 // ProviderType represents the type of LLM provider
 type ProviderType string
 
@@ -17,12 +51,12 @@ const (
 	ProviderOllama   ProviderType = "ollama"
 )
 
-// DefaultModels for each provider
+// DefaultModels for each provider, as defined by config.DefaultModels
 var DefaultModels = map[ProviderType]string{
-	ProviderGroq:     "llama-3.3-70b-versatile",
-	ProviderOpenAI:   "gpt-4o-mini",
-	ProviderAnthropic: "claude-3-5-sonnet-20241022",
-	ProviderOllama:   "llama3.2",
+	ProviderGroq:     config.DefaultModels[string(ProviderGroq)],
+	ProviderOpenAI:   config.DefaultModels[string(ProviderOpenAI)],
+	ProviderAnthropic: config.DefaultModels[string(ProviderAnthropic)],
+	ProviderOllama:   config.DefaultModels[string(ProviderOllama)],
 }
 
 // ProviderEndpoints for each provider
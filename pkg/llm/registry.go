@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProviderInfo describes a provider that can be selected by name
+type ProviderInfo struct {
+	Name        string          // Lowercase name used with `config set provider`
+	Description string          // One line for listings
+	Builtin     bool            // Compiled in, as opposed to defined in config
+	New         func() Provider // Creates a client from the current config
+}
+
+// ProviderSource lists providers defined at runtime, e.g. in the config file
+type ProviderSource func() []ProviderInfo
+
+var (
+	builtinProviders = make(map[string]ProviderInfo)
+	providerSources  []ProviderSource
+)
+
+// RegisterProvider adds a built-in provider. It panics on duplicate names,
+// so providers are expected to register from init functions.
+func RegisterProvider(info ProviderInfo) {
+	if info.Name == "" || info.New == nil {
+		panic("llm: provider needs a name and a New function")
+	}
+	info.Name = strings.ToLower(info.Name)
+	if _, exists := builtinProviders[info.Name]; exists {
+		panic(fmt.Sprintf("llm: provider '%s' registered twice", info.Name))
+	}
+	info.Builtin = true
+	builtinProviders[info.Name] = info
+}
+
+// RegisterProviderSource adds a source of providers defined at runtime
+func RegisterProviderSource(src ProviderSource) {
+	providerSources = append(providerSources, src)
+}
+
+// Providers returns every selectable provider: built-ins first, then
+// runtime-defined ones, each group ordered by name. A runtime provider
+// cannot shadow a built-in or an earlier source.
+func Providers() []ProviderInfo {
+	var builtins []ProviderInfo
+	for _, info := range builtinProviders {
+		builtins = append(builtins, info)
+	}
+	sortProviders(builtins)
+
+	seen := make(map[string]bool)
+	for _, info := range builtins {
+		seen[info.Name] = true
+	}
+
+	var defined []ProviderInfo
+	for _, src := range providerSources {
+		for _, info := range src() {
+			info.Name = strings.ToLower(info.Name)
+			if info.Name == "" || info.New == nil || seen[info.Name] {
+				continue
+			}
+			seen[info.Name] = true
+			defined = append(defined, info)
+		}
+	}
+	sortProviders(defined)
+
+	return append(builtins, defined...)
+}
+
+// ProviderNames returns the names of all selectable providers
+func ProviderNames() []string {
+	var names []string
+	for _, info := range Providers() {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// LookupProvider finds a provider by name
+func LookupProvider(name string) (ProviderInfo, bool) {
+	name = strings.ToLower(name)
+	if info, ok := builtinProviders[name]; ok {
+		return info, true
+	}
+	for _, info := range Providers() {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return ProviderInfo{}, false
+}
+
+func sortProviders(infos []ProviderInfo) {
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+}
@@ -0,0 +1,222 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt
+	BaseDelay  time.Duration // Backoff before the first retry; doubles each time
+	MaxDelay   time.Duration // Cap on a single computed backoff
+	MaxWait    time.Duration // Retry budget: total time spent waiting across retries
+}
+
+// DefaultRetryPolicy is used unless the config overrides it
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   20 * time.Second,
+	MaxWait:    60 * time.Second,
+}
+
+// ConfiguredRetryPolicy applies max_retries and retry_max_wait from the config
+func ConfiguredRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy
+	if n, ok := config.GetMaxRetries(); ok {
+		policy.MaxRetries = n
+	}
+	if d, ok := config.GetRetryMaxWait(); ok {
+		policy.MaxWait = d
+	}
+	return policy
+}
+
+// backoff returns the jittered exponential delay before retry n (0-based)
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// "Equal jitter": half fixed, half random, so concurrent clients spread out
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// RetryTransport retries rate-limited, overloaded and transiently failing
+// requests with exponential backoff and jitter, honouring the provider's
+// Retry-After and rate-limit reset headers. It gives up once the retry
+// budget is spent and hands the last response to the caller.
+type RetryTransport struct {
+	Base   http.RoundTripper
+	Policy *RetryPolicy // nil loads the policy from config on first use
+
+	once   sync.Once
+	policy RetryPolicy
+}
+
+func (t *RetryTransport) currentPolicy() RetryPolicy {
+	t.once.Do(func() {
+		if t.Policy != nil {
+			t.policy = *t.Policy
+		} else {
+			t.policy = ConfiguredRetryPolicy()
+		}
+	})
+	return t.policy
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := t.currentPolicy()
+	ctx := req.Context()
+	var waited time.Duration
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				// Body can't be replayed; nothing more we can do
+				return nil, errors.New("request body cannot be retried")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base().RoundTrip(req)
+		retry, delay := shouldRetry(ctx, resp, err)
+		if !retry || attempt >= policy.MaxRetries {
+			return resp, err
+		}
+		if delay <= 0 {
+			delay = policy.backoff(attempt)
+		}
+		if waited+delay > policy.MaxWait {
+			// Out of budget; let the caller report the failure
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		waited += delay
+	}
+}
+
+// shouldRetry decides whether a result is worth retrying and returns the
+// delay the server asked for, if any
+func shouldRetry(ctx context.Context, resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		return ctx.Err() == nil && isTransientNetworkError(err), 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// A 429 can also mean "out of credits", which waiting won't fix
+		if isQuotaMessage(peekBody(resp)) {
+			return false, 0
+		}
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout,
+		529: // Anthropic "overloaded"
+	default:
+		return false, 0
+	}
+	return true, retryDelay(resp.Header, time.Now())
+}
+
+// peekBody reads a (small) error body and puts it back for the caller
+func peekBody(resp *http.Response) string {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data)
+}
+
+// isTransientNetworkError reports errors that may go away on retry. Refused
+// connections are not retried: the server (usually a local Ollama) is down.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryDelay reads how long the server wants us to wait from Retry-After,
+// retry-after-ms, or the OpenAI/Groq (x-ratelimit-*) and Anthropic
+// (anthropic-ratelimit-*) reset headers for an exhausted limit
+func retryDelay(h http.Header, now time.Time) time.Duration {
+	if ms := h.Get("retry-after-ms"); ms != "" {
+		if n, err := strconv.ParseFloat(ms, 64); err == nil && n > 0 {
+			return time.Duration(n * float64(time.Millisecond))
+		}
+	}
+	if ra := h.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.ParseFloat(ra, 64); err == nil && secs > 0 {
+			return time.Duration(secs * float64(time.Second))
+		}
+		if t, err := http.ParseTime(ra); err == nil && t.After(now) {
+			return t.Sub(now)
+		}
+	}
+
+	var delay time.Duration
+	for _, limit := range []string{"requests", "tokens"} {
+		// OpenAI and Groq: x-ratelimit-remaining-requests: 0, x-ratelimit-reset-requests: 1m2s
+		if strings.TrimSpace(h.Get("x-ratelimit-remaining-"+limit)) == "0" {
+			if d, err := time.ParseDuration(h.Get("x-ratelimit-reset-" + limit)); err == nil && d > delay {
+				delay = d
+			}
+		}
+	}
+	for _, limit := range []string{"requests", "tokens", "input-tokens", "output-tokens"} {
+		// Anthropic: anthropic-ratelimit-requests-remaining: 0, anthropic-ratelimit-requests-reset: RFC 3339 time
+		if strings.TrimSpace(h.Get("anthropic-ratelimit-"+limit+"-remaining")) == "0" {
+			if t, err := time.Parse(time.RFC3339, h.Get("anthropic-ratelimit-"+limit+"-reset")); err == nil && t.Sub(now) > delay {
+				delay = t.Sub(now)
+			}
+		}
+	}
+	return delay
+}
@@ -0,0 +1,372 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastPolicy retries quickly so tests don't sleep through real backoff
+var fastPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond, MaxWait: 5 * time.Second}
+
+// step is one canned reply from a retryServer
+type step struct {
+	status  int
+	headers map[string]string
+	body    string
+}
+
+// retryServer answers with steps in order, repeating the last, and counts
+// the requests it gets
+func retryServer(t *testing.T, steps ...step) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&hits, 1)) - 1
+		if n >= len(steps) {
+			n = len(steps) - 1
+		}
+		// Every attempt carries the full body
+		if body, _ := io.ReadAll(r.Body); r.Method == "POST" && string(body) != "payload" {
+			t.Errorf("attempt %d sent body %q", n+1, body)
+		}
+		for k, v := range steps[n].headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(steps[n].status)
+		io.WriteString(w, steps[n].body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+// postPayload sends a replayable POST through transport
+func postPayload(ctx context.Context, transport http.RoundTripper, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader("payload"))
+	if err != nil {
+		return nil, err
+	}
+	return (&http.Client{Transport: transport}).Do(req)
+}
+
+func TestRetryTransport(t *testing.T) {
+	ok := step{status: 200, body: "ok"}
+	tests := []struct {
+		name       string
+		steps      []step
+		policy     RetryPolicy
+		wantStatus int
+		wantHits   int32
+	}{
+		{
+			name:       "success is not retried",
+			steps:      []step{ok},
+			wantStatus: 200,
+			wantHits:   1,
+		},
+		{
+			name:       "429 then success",
+			steps:      []step{{status: 429}, ok},
+			wantStatus: 200,
+			wantHits:   2,
+		},
+		{
+			name:       "503 twice then success",
+			steps:      []step{{status: 503}, {status: 503}, ok},
+			wantStatus: 200,
+			wantHits:   3,
+		},
+		{
+			name:       "Anthropic overloaded",
+			steps:      []step{{status: 529}, ok},
+			wantStatus: 200,
+			wantHits:   2,
+		},
+		{
+			name:       "gives up after MaxRetries and returns the last response",
+			steps:      []step{{status: 503}},
+			wantStatus: 503,
+			wantHits:   4,
+		},
+		{
+			name:       "MaxRetries 0 never retries",
+			steps:      []step{{status: 503}, ok},
+			policy:     RetryPolicy{MaxWait: time.Second},
+			wantStatus: 503,
+			wantHits:   1,
+		},
+		{
+			name:       "429 for an exhausted quota is not retried",
+			steps:      []step{{status: 429, body: `{"error":{"message":"You exceeded your current quota"}}`}, ok},
+			wantStatus: 429,
+			wantHits:   1,
+		},
+		{
+			name:       "bad request is not retried",
+			steps:      []step{{status: 400}, ok},
+			wantStatus: 400,
+			wantHits:   1,
+		},
+		{
+			name:       "auth failure is not retried",
+			steps:      []step{{status: 401}, ok},
+			wantStatus: 401,
+			wantHits:   1,
+		},
+		{
+			name:       "not found is not retried",
+			steps:      []step{{status: 404}, ok},
+			wantStatus: 404,
+			wantHits:   1,
+		},
+		{
+			name:       "Retry-After in seconds beyond the budget gives up at once",
+			steps:      []step{{status: 429, headers: map[string]string{"Retry-After": "120"}}, ok},
+			wantStatus: 429,
+			wantHits:   1,
+		},
+		{
+			name:       "Retry-After as an HTTP date beyond the budget gives up at once",
+			steps:      []step{{status: 503, headers: map[string]string{"Retry-After": time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}, ok},
+			wantStatus: 503,
+			wantHits:   1,
+		},
+		{
+			name:       "exhausted rate limit reset beyond the budget gives up at once",
+			steps:      []step{{status: 429, headers: map[string]string{"x-ratelimit-remaining-requests": "0", "x-ratelimit-reset-requests": "2m"}}, ok},
+			wantStatus: 429,
+			wantHits:   1,
+		},
+		{
+			name:       "Retry-After within the budget is honoured",
+			steps:      []step{{status: 429, headers: map[string]string{"retry-after-ms": "20"}}, ok},
+			wantStatus: 200,
+			wantHits:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := retryServer(t, tt.steps...)
+			policy := tt.policy
+			if policy == (RetryPolicy{}) {
+				policy = fastPolicy
+			}
+			resp, err := postPayload(context.Background(), &RetryTransport{Policy: &policy}, srv.URL)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(hits); got != tt.wantHits {
+				t.Errorf("requests = %d, want %d", got, tt.wantHits)
+			}
+			// The caller can still read the final response
+			last := tt.steps[len(tt.steps)-1]
+			if int(tt.wantHits) < len(tt.steps) {
+				last = tt.steps[tt.wantHits-1]
+			}
+			if body, _ := io.ReadAll(resp.Body); string(body) != last.body {
+				t.Errorf("body = %q, want %q", body, last.body)
+			}
+		})
+	}
+}
+
+func TestRetryTransportWaits(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func() string
+		min        time.Duration
+	}{
+		{"Retry-After in seconds", func() string { return "1" }, 900 * time.Millisecond},
+		// HTTP dates have one-second resolution, so ask for two
+		{"Retry-After as an HTTP date", func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) }, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := retryServer(t, step{status: 503, headers: map[string]string{"Retry-After": tt.retryAfter()}}, step{status: 200})
+			start := time.Now()
+			resp, err := postPayload(context.Background(), &RetryTransport{Policy: &fastPolicy}, srv.URL)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			resp.Body.Close()
+			if elapsed := time.Since(start); elapsed < tt.min {
+				t.Errorf("retried after %v, want at least %v", elapsed, tt.min)
+			}
+			if resp.StatusCode != 200 || atomic.LoadInt32(hits) != 2 {
+				t.Errorf("status %d after %d requests, want 200 after 2", resp.StatusCode, atomic.LoadInt32(hits))
+			}
+		})
+	}
+}
+
+func TestRetryTransportBodyNotReplayable(t *testing.T) {
+	srv, hits := retryServer(t, step{status: 503}, step{status: 200})
+
+	// A plain io.Reader has no GetBody, so the request can't be sent twice
+	req, err := http.NewRequest("POST", srv.URL, io.MultiReader(strings.NewReader("payload")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetBody != nil {
+		t.Fatal("request body is replayable")
+	}
+	_, err = (&http.Client{Transport: &RetryTransport{Policy: &fastPolicy}}).Do(req)
+	if err == nil || !strings.Contains(err.Error(), "request body cannot be retried") {
+		t.Errorf("error = %v, want the body to be unretryable", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestRetryTransportContextCancel(t *testing.T) {
+	srv, hits := retryServer(t, step{status: 503, headers: map[string]string{"Retry-After": "3"}}, step{status: 200})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := postPayload(ctx, &RetryTransport{Policy: &fastPolicy}, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("waited %v after the context ended", elapsed)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestRetryTransportNetworkErrors(t *testing.T) {
+	t.Run("dropped connection is retried", func(t *testing.T) {
+		var hits int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&hits, 1) == 1 {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			io.WriteString(w, "ok")
+		}))
+		defer srv.Close()
+
+		resp, err := postPayload(context.Background(), &RetryTransport{Policy: &fastPolicy}, srv.URL)
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		resp.Body.Close()
+		if got := atomic.LoadInt32(&hits); got != 2 {
+			t.Errorf("requests = %d, want 2", got)
+		}
+	})
+
+	t.Run("refused connection is not retried", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		url := srv.URL
+		srv.Close()
+
+		var attempts int32
+		base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&attempts, 1)
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		if _, err := postPayload(context.Background(), &RetryTransport{Base: base, Policy: &fastPolicy}, url); err == nil {
+			t.Fatal("request to a closed server succeeded")
+		}
+		if got := atomic.LoadInt32(&attempts); got != 1 {
+			t.Errorf("attempts = %d, want 1", got)
+		}
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{"none", nil, 0},
+		{"seconds", map[string]string{"Retry-After": "7"}, 7 * time.Second},
+		{"fractional seconds", map[string]string{"Retry-After": "1.5"}, 1500 * time.Millisecond},
+		{"HTTP date", map[string]string{"Retry-After": now.Add(30 * time.Second).Format(http.TimeFormat)}, 30 * time.Second},
+		{"HTTP date in the past", map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)}, 0},
+		{"garbage", map[string]string{"Retry-After": "soon"}, 0},
+		{"retry-after-ms wins", map[string]string{"retry-after-ms": "250", "Retry-After": "9"}, 250 * time.Millisecond},
+		{
+			"OpenAI reset for an exhausted limit",
+			map[string]string{"x-ratelimit-remaining-requests": "0", "x-ratelimit-reset-requests": "1m2s"},
+			62 * time.Second,
+		},
+		{
+			"reset ignored while requests remain",
+			map[string]string{"x-ratelimit-remaining-requests": "5", "x-ratelimit-reset-requests": "1m"},
+			0,
+		},
+		{
+			"longest exhausted limit wins",
+			map[string]string{
+				"x-ratelimit-remaining-requests": "0", "x-ratelimit-reset-requests": "2s",
+				"x-ratelimit-remaining-tokens": "0", "x-ratelimit-reset-tokens": "6s",
+			},
+			6 * time.Second,
+		},
+		{
+			"Anthropic reset time",
+			map[string]string{
+				"anthropic-ratelimit-tokens-remaining": "0",
+				"anthropic-ratelimit-tokens-reset":     now.Add(45 * time.Second).Format(time.RFC3339),
+			},
+			45 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			if got := retryDelay(h, now); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		n        int
+		low, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond, 800 * time.Millisecond},
+		{4, 500 * time.Millisecond, time.Second}, // Capped
+		{30, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(tt.n); d < tt.low || d > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.n, d, tt.low, tt.max)
+			}
+		}
+	}
+	if d := (RetryPolicy{}).backoff(2); d != 0 {
+		t.Errorf("backoff with no base delay = %v, want 0", d)
+	}
+}
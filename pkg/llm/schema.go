@@ -0,0 +1,197 @@
+package llm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema used to describe structured replies
+type Schema struct {
+	Type                 string             `json:"type"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+}
+
+// SchemaFor derives a schema from a Go value, usually a pointer to a
+// struct. Fields follow their json tags; fields tagged omitempty are
+// optional. A `desc` tag becomes the property description and an `enum`
+// tag (comma-separated) restricts a string field.
+func SchemaFor(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("cannot derive a schema from nil")
+	}
+	return schemaForType(t)
+}
+
+func schemaForType(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Struct:
+		return schemaForStruct(t)
+	}
+	return nil, fmt.Errorf("unsupported type %s in schema", t)
+}
+
+func schemaForStruct(t reflect.Type) (*Schema, error) {
+	closed := false
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &closed}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := schemaForType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		prop.Description = f.Tag.Get("desc")
+		if enum := f.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+
+		s.Properties[name] = prop
+		if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s, nil
+}
+
+// Validate checks a value decoded by encoding/json into interface{}
+// against the schema, reporting every problem found
+func (s *Schema) Validate(v interface{}) error {
+	var problems []string
+	s.validate("$", v, &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(problems, "; "))
+}
+
+func (s *Schema) validate(path string, v interface{}, problems *[]string) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			report("expected an object, got %s", jsonTypeName(v))
+			return
+		}
+		for _, name := range s.Required {
+			if val, ok := obj[name]; !ok || val == nil {
+				report("missing required field %q", name)
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					report("unexpected field %q", k)
+				}
+				continue
+			}
+			if obj[k] != nil {
+				prop.validate(path+"."+k, obj[k], problems)
+			}
+		}
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			report("expected an array, got %s", jsonTypeName(v))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			report("expected a string, got %s", jsonTypeName(v))
+			return
+		}
+		if len(s.Enum) > 0 {
+			for _, e := range s.Enum {
+				if str == e {
+					return
+				}
+			}
+			report("%q is not one of %s", str, strings.Join(s.Enum, ", "))
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			report("expected an integer, got %s", jsonTypeName(v))
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			report("expected a number, got %s", jsonTypeName(v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			report("expected a boolean, got %s", jsonTypeName(v))
+		}
+	}
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
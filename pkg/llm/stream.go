@@ -0,0 +1,107 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// StreamFunc receives each piece of text as it arrives. Returning an
+// error stops the stream and is returned to the caller.
+type StreamFunc func(chunk string) error
+
+// Streamer is implemented by providers that can stream completions
+type Streamer interface {
+	// Stream works like Chat but calls fn with each text delta as it
+	// arrives. The returned Response holds the full text.
+	Stream(ctx context.Context, req Request, fn StreamFunc) (*Response, error)
+}
+
+// ChatStream streams a completion from p, falling back to a single
+// callback with the whole reply for providers that cannot stream and
+// for structured (Format) requests
+func ChatStream(ctx context.Context, p Provider, req Request, fn StreamFunc) (*Response, error) {
+	if s, ok := p.(Streamer); ok && req.Format == nil {
+		return s.Stream(ctx, req, fn)
+	}
+	resp, err := p.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(resp.Content); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// errStreamDone ends a stream early without reporting an error
+var errStreamDone = errors.New("stream done")
+
+// maxStreamLine bounds a single SSE or NDJSON line
+const maxStreamLine = 1024 * 1024
+
+// readSSE parses a server-sent event stream, calling fn with the event
+// name and data of each event. fn may return errStreamDone to stop.
+func readSSE(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+
+	var event string
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			event = ""
+			return nil
+		}
+		err := fn(event, strings.Join(data, "\n"))
+		event, data = "", nil
+		return err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return ignoreDone(err)
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	// A stream may end without a trailing blank line
+	return ignoreDone(dispatch())
+}
+
+// readNDJSON calls fn with each non-empty line of a newline-delimited
+// JSON stream. fn may return errStreamDone to stop.
+func readNDJSON(r io.Reader, fn func(line []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return ignoreDone(err)
+		}
+	}
+	return scanner.Err()
+}
+
+func ignoreDone(err error) error {
+	if errors.Is(err, errStreamDone) {
+		return nil
+	}
+	return err
+}
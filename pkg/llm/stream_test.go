@@ -0,0 +1,504 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSSE(t *testing.T) {
+	stop := fmt.Errorf("stop: %w", errStreamDone)
+	tests := []struct {
+		name   string
+		stream string
+		stopAt string // Data that makes the callback stop the stream
+		want   []string
+	}{
+		{
+			name:   "events with names and comments",
+			stream: ": keep-alive\n\nevent: delta\ndata: one\n\ndata:two\n\n",
+			want:   []string{"delta|one", "|two"},
+		},
+		{
+			name:   "multi-line data is joined",
+			stream: "data: a\ndata: b\n\n",
+			want:   []string{"|a\nb"},
+		},
+		{
+			name:   "last event without a trailing blank line",
+			stream: "data: one\n\ndata: two",
+			want:   []string{"|one", "|two"},
+		},
+		{
+			name:   "blank lines without data dispatch nothing",
+			stream: "\n\nevent: ping\n\n\ndata: x\n\n",
+			want:   []string{"|x"},
+		},
+		{
+			name:   "an event name doesn't carry over to the next event",
+			stream: "event: ping\n\ndata: x\n\n",
+			want:   []string{"|x"},
+		},
+		{
+			name:   "stopping early is not an error",
+			stream: "data: one\n\ndata: [DONE]\n\ndata: after\n\n",
+			stopAt: "[DONE]",
+			want:   []string{"|one", "|[DONE]"},
+		},
+		{
+			name:   "CRLF line endings",
+			stream: "data: one\r\n\r\ndata: two\r\n\r\n",
+			want:   []string{"|one", "|two"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := readSSE(strings.NewReader(tt.stream), func(event, data string) error {
+				got = append(got, event+"|"+data)
+				if tt.stopAt != "" && data == tt.stopAt {
+					return stop
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("readSSE() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readSSE() events = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("callback errors are returned", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := readSSE(strings.NewReader("data: x\n\n"), func(string, string) error { return boom })
+		if err != boom {
+			t.Errorf("readSSE() error = %v, want %v", err, boom)
+		}
+	})
+}
+
+func TestReadNDJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		stopAt string
+		want   []string
+	}{
+		{"lines", `{"a":1}` + "\n" + `{"a":2}` + "\n", "", []string{`{"a":1}`, `{"a":2}`}},
+		{"blank lines are skipped", "\n" + `{"a":1}` + "\n  \n" + `{"a":2}`, "", []string{`{"a":1}`, `{"a":2}`}},
+		{"stopping early", `{"done":true}` + "\n" + `{"late":1}`, `{"done":true}`, []string{`{"done":true}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := readNDJSON(strings.NewReader(tt.stream), func(line []byte) error {
+				got = append(got, string(line))
+				if string(line) == tt.stopAt {
+					return errStreamDone
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("readNDJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readNDJSON() lines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// useTestClient sends provider requests through a client that never
+// retries, restoring HTTPClient when the test ends
+func useTestClient(t *testing.T) {
+	t.Helper()
+	saved := HTTPClient
+	HTTPClient = &http.Client{Transport: &RetryTransport{Policy: &RetryPolicy{}}}
+	t.Cleanup(func() { HTTPClient = saved })
+}
+
+// streamServer writes each part of a response body, flushing in between
+func streamServer(t *testing.T, contentType string, parts ...string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		for _, part := range parts {
+			fmt.Fprint(w, part)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// collect streams req and returns the chunks fn saw
+func collect(ctx context.Context, s Streamer) ([]string, *Response, error) {
+	var chunks []string
+	resp, err := s.Stream(ctx, PromptRequest("hi"), func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	return chunks, resp, err
+}
+
+func TestStreamChatCompletion(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []string
+		chunks  []string
+		want    Response
+		wantErr string
+	}{
+		{
+			name: "deltas until [DONE]",
+			events: []string{
+				`{"model":"m-1","choices":[{"delta":{"role":"assistant"}}]}`,
+				`{"choices":[{"delta":{"content":"Hel"}}]}`,
+				`{"choices":[{"delta":{"content":"lo"},"finish_reason":null}]}`,
+				`{"choices":[{"delta":{},"finish_reason":"stop"}]}`,
+				`[DONE]`,
+				`{"choices":[{"delta":{"content":"ignored"}}]}`,
+			},
+			chunks: []string{"Hel", "lo"},
+			want:   Response{Content: "Hello", Model: "m-1", FinishReason: "stop"},
+		},
+		{
+			name: "usage in a trailing chunk with no choices",
+			events: []string{
+				`{"choices":[{"delta":{"content":"ok"},"finish_reason":"stop"}]}`,
+				`{"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3}}`,
+				`[DONE]`,
+			},
+			chunks: []string{"ok"},
+			want:   Response{Content: "ok", Model: "test-model", FinishReason: "stop", Usage: Usage{PromptTokens: 12, CompletionTokens: 3}},
+		},
+		{
+			name: "Groq usage under x_groq",
+			events: []string{
+				`{"choices":[{"delta":{"content":"ok"}}]}`,
+				`{"choices":[{"delta":{},"finish_reason":"length"}],"x_groq":{"usage":{"prompt_tokens":5,"completion_tokens":1}}}`,
+				`[DONE]`,
+			},
+			chunks: []string{"ok"},
+			want:   Response{Content: "ok", Model: "test-model", FinishReason: "length", Usage: Usage{PromptTokens: 5, CompletionTokens: 1}},
+		},
+		{
+			name:   "stream that ends without [DONE]",
+			events: []string{`{"choices":[{"delta":{"content":"cut"}}]}`},
+			chunks: []string{"cut"},
+			want:   Response{Content: "cut", Model: "test-model"},
+		},
+		{
+			name: "error event mid-stream",
+			events: []string{
+				`{"choices":[{"delta":{"content":"par"}}]}`,
+				`{"error":{"message":"overloaded"}}`,
+			},
+			chunks:  []string{"par"},
+			wantErr: "Test API error: overloaded",
+		},
+		{
+			name:    "malformed event",
+			events:  []string{`{"choices":`},
+			wantErr: "invalid Test stream event",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestClient(t)
+			var parts []string
+			for _, e := range tt.events {
+				parts = append(parts, "data: "+e+"\n\n")
+			}
+			srv := streamServer(t, "text/event-stream", parts...)
+			api := chatAPI{name: "Test", url: srv.URL, model: "test-model"}
+
+			var chunks []string
+			resp, err := streamChatCompletion(context.Background(), api, PromptRequest("hi"), func(chunk string) error {
+				chunks = append(chunks, chunk)
+				return nil
+			})
+			if !reflect.DeepEqual(chunks, tt.chunks) {
+				t.Errorf("chunks = %q, want %q", chunks, tt.chunks)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if *resp != tt.want {
+				t.Errorf("response = %+v, want %+v", *resp, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamChatCompletionRequest(t *testing.T) {
+	useTestClient(t)
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	for _, streamUsage := range []bool{false, true} {
+		api := chatAPI{name: "Test", url: srv.URL, model: "m", streamUsage: streamUsage}
+		if _, err := streamChatCompletion(context.Background(), api, PromptRequest("hi"), func(string) error { return nil }); err != nil {
+			t.Fatalf("error = %v", err)
+		}
+		if !strings.Contains(body, `"stream":true`) {
+			t.Errorf("request %s doesn't ask for a stream", body)
+		}
+		if got := strings.Contains(body, `"include_usage":true`); got != streamUsage {
+			t.Errorf("streamUsage = %v but request is %s", streamUsage, body)
+		}
+	}
+}
+
+func TestStreamServerErrors(t *testing.T) {
+	useTestClient(t)
+
+	t.Run("error status before the stream", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"message":"try later"}}`)
+		}))
+		defer srv.Close()
+
+		_, err := streamChatCompletion(context.Background(), chatAPI{name: "Test", url: srv.URL}, PromptRequest("hi"), func(string) error { return nil })
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Kind != ErrServer || apiErr.Message != "try later" {
+			t.Fatalf("error = %#v, want a server APIError", err)
+		}
+	})
+
+	t.Run("connection dropped mid-stream", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"par\"}}]}\n\n")
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		}))
+		defer srv.Close()
+
+		var chunks []string
+		_, err := streamChatCompletion(context.Background(), chatAPI{name: "Test", url: srv.URL}, PromptRequest("hi"), func(chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		if err == nil {
+			t.Fatalf("a truncated stream succeeded")
+		}
+		if !reflect.DeepEqual(chunks, []string{"par"}) {
+			t.Errorf("chunks = %q, want the part before the drop", chunks)
+		}
+	})
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		srv := streamServer(t, "text/event-stream",
+			"data: {\"choices\":[{\"delta\":{\"content\":\"a\"}}]}\n\n",
+			"data: {\"choices\":[{\"delta\":{\"content\":\"b\"}}]}\n\n",
+		)
+		stop := errors.New("stop")
+		calls := 0
+		_, err := streamChatCompletion(context.Background(), chatAPI{name: "Test", url: srv.URL}, PromptRequest("hi"), func(string) error {
+			calls++
+			return stop
+		})
+		if err != stop || calls != 1 {
+			t.Errorf("error = %v after %d calls, want %v after 1", err, calls, stop)
+		}
+	})
+}
+
+func TestStreamContextCancel(t *testing.T) {
+	useTestClient(t)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"first\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		// Hold the stream open until the client goes away
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var chunks []string
+	_, err := streamChatCompletion(ctx, chatAPI{name: "Test", url: srv.URL}, PromptRequest("hi"), func(chunk string) error {
+		chunks = append(chunks, chunk)
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if !reflect.DeepEqual(chunks, []string{"first"}) {
+		t.Errorf("chunks = %q, want only the chunk before cancelling", chunks)
+	}
+}
+
+func TestAnthropicStream(t *testing.T) {
+	useTestClient(t)
+	tests := []struct {
+		name    string
+		events  []string // "event|data"
+		chunks  []string
+		want    Response
+		wantErr string
+	}{
+		{
+			name: "message until message_stop",
+			events: []string{
+				`message_start|{"type":"message_start","message":{"model":"claude-x","usage":{"input_tokens":9,"output_tokens":1}}}`,
+				`content_block_start|{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+				`ping|{"type":"ping"}`,
+				`content_block_delta|{"type":"content_block_delta","delta":{"type":"text_delta","text":"Hi"}}`,
+				`content_block_delta|{"type":"content_block_delta","delta":{"type":"input_json_delta","partial_json":"{"}}`,
+				`content_block_delta|{"type":"content_block_delta","delta":{"type":"text_delta","text":" there"}}`,
+				`message_delta|{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":4}}`,
+				`message_stop|{"type":"message_stop"}`,
+				`content_block_delta|{"type":"content_block_delta","delta":{"type":"text_delta","text":"late"}}`,
+			},
+			chunks: []string{"Hi", " there"},
+			want:   Response{Content: "Hi there", Model: "claude-x", FinishReason: "end_turn", Usage: Usage{PromptTokens: 9, CompletionTokens: 4}},
+		},
+		{
+			name: "error event mid-stream",
+			events: []string{
+				`content_block_delta|{"type":"content_block_delta","delta":{"type":"text_delta","text":"Hi"}}`,
+				`error|{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+			},
+			chunks:  []string{"Hi"},
+			wantErr: "Anthropic API error: Overloaded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []string
+			for _, e := range tt.events {
+				event, data, _ := strings.Cut(e, "|")
+				parts = append(parts, "event: "+event+"\ndata: "+data+"\n\n")
+			}
+			srv := streamServer(t, "text/event-stream", parts...)
+			chunks, resp, err := collect(context.Background(), &AnthropicClient{apiKey: "k", model: "claude", endpoint: srv.URL})
+			if !reflect.DeepEqual(chunks, tt.chunks) {
+				t.Errorf("chunks = %q, want %q", chunks, tt.chunks)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if *resp != tt.want {
+				t.Errorf("response = %+v, want %+v", *resp, tt.want)
+			}
+		})
+	}
+}
+
+func TestOllamaStream(t *testing.T) {
+	useTestClient(t)
+	tests := []struct {
+		name    string
+		lines   []string
+		chunks  []string
+		want    Response
+		wantErr string
+	}{
+		{
+			name: "lines until done, with usage on the last",
+			lines: []string{
+				`{"model":"llama3","message":{"role":"assistant","content":"Hel"},"done":false}`,
+				`{"model":"llama3","message":{"role":"assistant","content":"lo"},"done":false}`,
+				`{"model":"llama3","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":7,"eval_count":2}`,
+				`{"model":"llama3","message":{"content":"late"}}`,
+			},
+			chunks: []string{"Hel", "lo"},
+			want:   Response{Content: "Hello", Model: "llama3", FinishReason: "stop", Usage: Usage{PromptTokens: 7, CompletionTokens: 2}},
+		},
+		{
+			name: "error line mid-stream",
+			lines: []string{
+				`{"message":{"content":"Hel"}}`,
+				`{"error":"model crashed"}`,
+			},
+			chunks:  []string{"Hel"},
+			wantErr: "Ollama error: model crashed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []string
+			for _, line := range tt.lines {
+				parts = append(parts, line+"\n")
+			}
+			srv := streamServer(t, "application/x-ndjson", parts...)
+			chunks, resp, err := collect(context.Background(), &OllamaClient{model: "llama3:8b", endpoint: srv.URL})
+			if !reflect.DeepEqual(chunks, tt.chunks) {
+				t.Errorf("chunks = %q, want %q", chunks, tt.chunks)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if *resp != tt.want {
+				t.Errorf("response = %+v, want %+v", *resp, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatStreamFallback(t *testing.T) {
+	useTestClient(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"model":"m","choices":[{"message":{"content":"{\"a\":1}"},"finish_reason":"stop"}]}`)
+	}))
+	defer srv.Close()
+
+	// Structured requests don't stream; fn gets the whole reply once
+	req := PromptRequest("hi")
+	req.Format = &Format{Name: "reply", Schema: &Schema{Type: "object"}}
+	var chunks []string
+	resp, err := ChatStream(context.Background(), &Client{apiKey: "k", model: "m", endpoint: srv.URL}, req, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ChatStream() error = %v", err)
+	}
+	if resp.Content != `{"a":1}` || !reflect.DeepEqual(chunks, []string{`{"a":1}`}) {
+		t.Errorf("ChatStream() = %q with chunks %q", resp.Content, chunks)
+	}
+}
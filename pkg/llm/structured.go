@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format asks a provider for a JSON object matching Schema, using its
+// native JSON mode: response_format for OpenAI and Groq, a forced tool
+// call for Anthropic and format "json" for Ollama
+type Format struct {
+	Name   string  `json:"name"` // Short identifier such as "skill"; the Anthropic tool name
+	Schema *Schema `json:"schema"`
+}
+
+// MaxRepairs bounds how many times GenerateJSON sends an invalid reply
+// back to the model with the error before giving up
+var MaxRepairs = 2
+
+// StructuredError is returned when the model never produced a reply
+// matching the schema
+type StructuredError struct {
+	Attempts int
+	Raw      string // The last reply
+	Err      error  // Why the last reply was rejected
+}
+
+func (e *StructuredError) Error() string {
+	return fmt.Sprintf("model did not return valid JSON after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *StructuredError) Unwrap() error { return e.Err }
+
+// GenerateJSON runs req and decodes the reply into out, a pointer to a
+// struct whose fields define the expected JSON. Replies that don't parse
+// or don't match the schema are fed back to the model with the error,
+// up to MaxRepairs times.
+func GenerateJSON(ctx context.Context, p Provider, req Request, name string, out interface{}) error {
+	schema, err := SchemaFor(out)
+	if err != nil {
+		return err
+	}
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	instructions := "Respond with a single JSON object and nothing else: no prose, no markdown code fences. It must match this JSON Schema:\n" + string(schemaJSON)
+	if req.System != "" {
+		req.System += "\n\n" + instructions
+	} else {
+		req.System = instructions
+	}
+	req.Format = &Format{Name: name, Schema: schema}
+	req.Messages = append([]Message(nil), req.Messages...)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := p.Chat(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		err = decodeStructured(resp.Content, schema, out)
+		if err == nil {
+			return nil
+		}
+		if attempt > MaxRepairs {
+			return &StructuredError{Attempts: attempt, Raw: resp.Content, Err: err}
+		}
+
+		req.Messages = append(req.Messages,
+			Message{Role: RoleAssistant, Content: resp.Content},
+			Message{Role: RoleUser, Content: fmt.Sprintf("That reply was rejected: %v\n\nReply again with only the corrected JSON object.", err)},
+		)
+	}
+}
+
+// decodeStructured parses a reply, checks it against the schema and
+// decodes it into out
+func decodeStructured(raw string, schema *Schema, out interface{}) error {
+	text := ExtractJSON(raw)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := schema.Validate(value); err != nil {
+		return fmt.Errorf("does not match the schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// StripFence trims a reply and removes a markdown code fence around it
+func StripFence(raw string) string {
+	text := strings.TrimSpace(raw)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(text, "```")
+		text = strings.TrimSpace(text)
+	}
+	return text
+}
+
+// ExtractJSON strips markdown code fences and any prose around the
+// outermost JSON object, for models without a strict JSON mode
+func ExtractJSON(raw string) string {
+	text := StripFence(raw)
+	if strings.HasPrefix(text, "{") {
+		return text
+	}
+
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start >= 0 && end > start {
+		return text[start : end+1]
+	}
+	return text
+}
@@ -0,0 +1,237 @@
+package prompts
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"openskill/pkg/config"
+)
+
+//go:embed templates/*.tmpl
+var builtin embed.FS
+
+// ProjectDir holds per-project prompt overrides
+const ProjectDir = ".claude/prompts"
+
+// Names of the built-in prompts
+const (
+	Generate   = "generate"
+	Improve    = "improve"
+	Explain    = "explain"
+	Criteria   = "criteria"
+	Compliance = "compliance"
+	Pairwise   = "pairwise"
+)
+
+// Descriptions of each prompt, in display order
+var Descriptions = []struct {
+	Name        string
+	Description string
+}{
+	{Generate, "Expands a name and intent into a skill (add)"},
+	{Improve, "Reviews a skill and suggests better rules (improve)"},
+	{Explain, "Explains a skill in plain language (explain)"},
+	{Criteria, "Judges a response against test criteria (test --suite)"},
+	{Compliance, "Judges whether a response follows each skill rule (test --judge)"},
+	{Pairwise, "Picks the better of two responses to a prompt (eval compare)"},
+}
+
+// Where a prompt was loaded from
+const (
+	SourceBuiltin = "builtin"
+	SourceUser    = "user"
+	SourceProject = "project"
+)
+
+// Prompt is a resolved prompt template
+type Prompt struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Source      string `json:"source" yaml:"source"`                 // builtin, user or project
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"` // Override file, empty for builtin
+	Text        string `json:"-" yaml:"-"`
+}
+
+// SkillData is passed to prompts that describe one skill
+type SkillData struct {
+	Name        string
+	Description string
+	Rules       []string
+	Verbose     bool
+}
+
+// GenerateData is passed to the generate prompt
+type GenerateData struct {
+	Name   string
+	Intent string
+}
+
+// CriteriaData is passed to the criteria prompt
+type CriteriaData struct {
+	Input    string
+	Response string
+	Criteria []string
+}
+
+// ComplianceData is passed to the compliance prompt
+type ComplianceData struct {
+	Skill    string
+	Input    string
+	Response string
+	Rules    []string
+}
+
+// PairwiseData is passed to the pairwise prompt
+type PairwiseData struct {
+	Skill     string
+	Input     string
+	ResponseA string
+	ResponseB string
+}
+
+// UserDir returns ~/.openskill/prompts
+func UserDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prompts"), nil
+}
+
+// fileName is the template file for a prompt
+func fileName(name string) string {
+	return name + ".tmpl"
+}
+
+// description returns the description of a known prompt
+func description(name string) (string, bool) {
+	for _, d := range Descriptions {
+		if d.Name == name {
+			return d.Description, true
+		}
+	}
+	return "", false
+}
+
+// Builtin returns the embedded text of a prompt
+func Builtin(name string) (string, error) {
+	if _, ok := description(name); !ok {
+		return "", fmt.Errorf("unknown prompt '%s' (valid: %s)", name, strings.Join(names(), ", "))
+	}
+	data, err := builtin.ReadFile("templates/" + fileName(name))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Load resolves a prompt, preferring .claude/prompts, then
+// ~/.openskill/prompts, then the built-in template
+func Load(name string) (*Prompt, error) {
+	desc, ok := description(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt '%s' (valid: %s)", name, strings.Join(names(), ", "))
+	}
+
+	dirs := []struct{ source, dir string }{{SourceProject, ProjectDir}}
+	if userDir, err := UserDir(); err == nil {
+		dirs = append(dirs, struct{ source, dir string }{SourceUser, userDir})
+	}
+	for _, d := range dirs {
+		path := filepath.Join(d.dir, fileName(name))
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt %s: %w", path, err)
+		}
+		return &Prompt{Name: name, Description: desc, Source: d.source, Path: path, Text: string(data)}, nil
+	}
+
+	text, err := Builtin(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Prompt{Name: name, Description: desc, Source: SourceBuiltin, Text: text}, nil
+}
+
+// List resolves every prompt
+func List() ([]*Prompt, error) {
+	var out []*Prompt
+	for _, d := range Descriptions {
+		p, err := Load(d.Name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Render executes the resolved prompt with data
+func Render(name string, data interface{}) (string, error) {
+	p, err := Load(name)
+	if err != nil {
+		return "", err
+	}
+	return p.Render(data)
+}
+
+// Render executes the prompt template with data
+func (p *Prompt) Render(data interface{}) (string, error) {
+	where := p.Source
+	if p.Path != "" {
+		where = p.Path
+	}
+	tmpl, err := template.New(p.Name).Funcs(funcs).Parse(p.Text)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template (%s): %w", where, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt (%s): %w", where, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// Eject writes the built-in text of a prompt to dir for customising. An
+// existing file is only replaced when force is set.
+func Eject(name, dir string, force bool) (string, error) {
+	text, err := Builtin(name)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fileName(name))
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// funcs are available to every prompt template
+var funcs = template.FuncMap{
+	"inc":   func(i int) int { return i + 1 },
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+func names() []string {
+	var out []string
+	for _, d := range Descriptions {
+		out = append(out, d.Name)
+	}
+	return out
+}
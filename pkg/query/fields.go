@@ -0,0 +1,184 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"openskill/pkg/core"
+)
+
+// Field describes a skill attribute that can be filtered, sorted or projected
+type Field struct {
+	Name    string
+	Help    string
+	Summary bool // Render as an item count in tables (e.g. rules)
+	get     func(s *core.Skill) interface{}
+}
+
+// fields lists every queryable attribute. Values are string, int or []string.
+var fields = []Field{
+	{Name: "name", Help: "Skill name", get: func(s *core.Skill) interface{} { return s.Name }},
+	{Name: "description", Help: "Skill description", get: func(s *core.Skill) interface{} { return s.Description }},
+	{Name: "rules", Help: "Rule texts; compares as a count against numbers", Summary: true, get: func(s *core.Skill) interface{} { return s.Rules }},
+	{Name: "tags", Help: "Tags", get: func(s *core.Skill) interface{} { return s.Tags }},
+	{Name: "group", Help: "Group name", get: func(s *core.Skill) interface{} { return s.Group }},
+	{Name: "author", Help: "Author", get: func(s *core.Skill) interface{} { return s.Author }},
+	{Name: "version", Help: "Semantic version", get: func(s *core.Skill) interface{} { return s.Version }},
+	{Name: "template", Help: "Template the skill was created from", get: func(s *core.Skill) interface{} { return s.Template }},
+	{Name: "extends", Help: "Parent skill", get: func(s *core.Skill) interface{} { return s.Extends }},
+	{Name: "includes", Help: "Included skills", get: func(s *core.Skill) interface{} { return s.Includes }},
+	{Name: "chain", Help: "Chained skills", get: func(s *core.Skill) interface{} { return s.Chain }},
+	{Name: "output_format", Help: "Expected output format", get: func(s *core.Skill) interface{} { return s.OutputFormat }},
+}
+
+// Fields returns all queryable fields
+func Fields() []Field {
+	return append([]Field(nil), fields...)
+}
+
+// FieldNames returns the names of all queryable fields
+func FieldNames() []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// LookupField finds a field by name (case-insensitive)
+func LookupField(name string) (Field, error) {
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+	}
+	return Field{}, fmt.Errorf("unknown field '%s' (valid: %s)", name, strings.Join(FieldNames(), ", "))
+}
+
+// Value returns the raw value of a field for a skill
+func (f Field) Value(s *core.Skill) interface{} {
+	return f.get(s)
+}
+
+// Display renders a field value as a single table or CSV cell
+func (f Field) Display(s *core.Skill) string {
+	switch v := f.get(s).(type) {
+	case []string:
+		if f.Summary {
+			return strconv.Itoa(len(v))
+		}
+		return strings.Join(v, ", ")
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ParseColumns parses a comma-separated list of field names
+func ParseColumns(spec string) ([]Field, error) {
+	var cols []Field
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		f, err := LookupField(name)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, f)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return cols, nil
+}
+
+// Project returns the selected columns of a skill keyed by field name
+func Project(s *core.Skill, cols []Field) map[string]interface{} {
+	row := make(map[string]interface{}, len(cols))
+	for _, c := range cols {
+		row[c.Name] = c.Value(s)
+	}
+	return row
+}
+
+// SortKey orders skills by one field
+type SortKey struct {
+	Field      Field
+	Descending bool
+}
+
+// ParseSort parses a sort spec like "-rules,name" (leading '-' sorts descending)
+func ParseSort(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := false
+		if strings.HasPrefix(part, "-") {
+			desc = true
+			part = part[1:]
+		} else {
+			part = strings.TrimPrefix(part, "+")
+		}
+		f, err := LookupField(part)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, SortKey{Field: f, Descending: desc})
+	}
+	return keys, nil
+}
+
+// Sort orders skills in place by the given keys, keeping ties stable
+func Sort(skills []core.Skill, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(skills, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareValues(k.Field.Value(&skills[i]), k.Field.Value(&skills[j]))
+			if c == 0 {
+				continue
+			}
+			if k.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// compareValues orders two values of the same field the way --where does:
+// version numbers numerically, other strings case-insensitively. Lists
+// compare by length.
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		return compareScalars(av, b.(string))
+	case int:
+		return compareInts(av, b.(int))
+	case []string:
+		return compareInts(len(av), len(b.([]string)))
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"openskill/pkg/core"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string // Field names, "-" prefixed when descending
+		wantErr string
+	}{
+		{spec: "", want: nil},
+		{spec: "name", want: []string{"name"}},
+		{spec: "-rules, +Name ,", want: []string{"-rules", "name"}},
+		{spec: "-version,group,-tags", want: []string{"-version", "group", "-tags"}},
+		{spec: "-bogus", wantErr: "unknown field 'bogus'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			keys, err := ParseSort(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSort() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+			var got []string
+			for _, k := range keys {
+				name := k.Field.Name
+				if k.Descending {
+					name = "-" + name
+				}
+				got = append(got, name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	skills := []core.Skill{
+		{Name: "beta", Rules: []string{"a"}, Group: "x", Version: "1.10.0"},
+		{Name: "Alpha", Rules: []string{"a", "b"}, Group: "y", Version: "1.9.2"},
+		{Name: "gamma", Rules: []string{"a"}, Group: "x", Version: "v2"},
+		{Name: "delta", Group: "y", Version: "1.9"},
+	}
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"", []string{"beta", "Alpha", "gamma", "delta"}},
+		{"name", []string{"Alpha", "beta", "delta", "gamma"}},
+		{"-name", []string{"gamma", "delta", "beta", "Alpha"}},
+		// Lists sort by length; ties keep their order
+		{"rules", []string{"delta", "beta", "gamma", "Alpha"}},
+		{"-rules", []string{"Alpha", "beta", "gamma", "delta"}},
+		{"group,-name", []string{"gamma", "beta", "delta", "Alpha"}},
+		{"-group,rules", []string{"delta", "Alpha", "beta", "gamma"}},
+		// Versions sort numerically, as --where compares them
+		{"version", []string{"delta", "Alpha", "beta", "gamma"}},
+		{"-version", []string{"gamma", "beta", "Alpha", "delta"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			keys, err := ParseSort(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+			list := append([]core.Skill(nil), skills...)
+			Sort(list, keys)
+			if got := skillNames(list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr string
+	}{
+		{spec: "name,tags", want: []string{"name", "tags"}},
+		{spec: " Name , RULES ", want: []string{"name", "rules"}},
+		{spec: ",,", wantErr: "no columns selected"},
+		{spec: "name,nope", wantErr: "unknown field 'nope'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			cols, err := ParseColumns(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseColumns() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseColumns() error = %v", err)
+			}
+			var got []string
+			for _, c := range cols {
+				got = append(got, c.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	s := &core.Skill{Name: "x", Tags: []string{"a", "b"}, Rules: []string{"r1", "r2", "r3"}}
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"name", "x"},
+		{"tags", "a, b"},
+		{"rules", "3"},
+		{"group", ""},
+	}
+	for _, tt := range tests {
+		f, err := LookupField(tt.field)
+		if err != nil {
+			t.Fatalf("LookupField(%q) error = %v", tt.field, err)
+		}
+		if got := f.Display(s); got != tt.want {
+			t.Errorf("%s.Display() = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
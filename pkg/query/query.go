@@ -0,0 +1,472 @@
+// Package query implements the filter expression language used by
+// `openskill list --where`.
+//
+// Grammar:
+//
+//	expr       := or
+//	or         := and ( "or" and )*
+//	and        := unary ( "and" unary )*
+//	unary      := "not" unary | "(" expr ")" | comparison
+//	comparison := field op value
+//	op         := = | != | < | <= | > | >= | has | contains | matches | ~
+//
+// Values are bare words, numbers or quoted strings. List fields (tags,
+// rules, includes, chain) compare by length against numbers, and use
+// membership for =, != and has.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"openskill/pkg/core"
+)
+
+// Expr is a parsed filter expression
+type Expr interface {
+	Match(s *core.Skill) bool
+	String() string
+}
+
+// Options customizes how expressions are evaluated
+type Options struct {
+	// TagMatch decides whether a skill tag satisfies a tag in the
+	// expression for =, != and has. Defaults to case-insensitive equality.
+	TagMatch func(tag, want string) bool
+}
+
+// Parse compiles a filter expression with default options
+func Parse(input string) (Expr, error) {
+	return ParseWith(input, Options{})
+}
+
+// ParseWith compiles a filter expression
+func ParseWith(input string, opts Options) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, opts: opts}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+	}
+	return expr, nil
+}
+
+// Filter returns the skills matching expr
+func Filter(skills []core.Skill, expr Expr) []core.Skill {
+	var out []core.Skill
+	for i := range skills {
+		if expr.Match(&skills[i]) {
+			out = append(out, skills[i])
+		}
+	}
+	return out
+}
+
+// ============== Lexer ==============
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+		case strings.ContainsRune("=!<>~&|", r):
+			start := i
+			op := string(r)
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				switch two {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					op = two
+				}
+			}
+			i += len([]rune(op))
+			switch op {
+			case "&&":
+				tokens = append(tokens, token{kind: tokWord, text: "and", pos: start})
+			case "||":
+				tokens = append(tokens, token{kind: tokWord, text: "or", pos: start})
+			case "!":
+				tokens = append(tokens, token{kind: tokWord, text: "not", pos: start})
+			case "&", "|":
+				return nil, fmt.Errorf("unexpected '%s' at position %d", op, start+1)
+			default:
+				tokens = append(tokens, token{kind: tokOp, text: op, pos: start})
+			}
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, start+1)
+			}
+			tokens = append(tokens, token{kind: tokWord, text: string(runes[start:i]), pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./*+:@", r)
+}
+
+// ============== Parser ==============
+
+type parser struct {
+	tokens []token
+	pos    int
+	opts   Options
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == tokWord && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.keyword("not") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner}, nil
+	}
+
+	if p.peek().kind == tokLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' but found %s at position %d", tok, tok.pos+1)
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	tok := p.next()
+	if tok.kind != tokWord {
+		return nil, fmt.Errorf("expected field name but found %s at position %d", tok, tok.pos+1)
+	}
+	field, err := LookupField(tok.text)
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.next()
+	var op string
+	switch {
+	case opTok.kind == tokOp:
+		op = opTok.text
+		if op == "==" {
+			op = "="
+		}
+		if op == "~" {
+			op = "matches"
+		}
+	case opTok.kind == tokWord && isKeywordOp(opTok.text):
+		op = strings.ToLower(opTok.text)
+	default:
+		return nil, fmt.Errorf("expected operator after '%s' but found %s at position %d", field.Name, opTok, opTok.pos+1)
+	}
+
+	valTok := p.next()
+	if valTok.kind != tokWord && valTok.kind != tokString {
+		return nil, fmt.Errorf("expected value after '%s' but found %s at position %d", op, valTok, valTok.pos+1)
+	}
+
+	cmp := &comparison{field: field, op: op, value: valTok.text}
+	if field.Name == "tags" {
+		cmp.tagMatch = p.opts.TagMatch
+	}
+	if n, err := strconv.Atoi(valTok.text); err == nil && valTok.kind == tokWord {
+		cmp.number = &n
+	}
+
+	if op == "matches" {
+		re, err := regexp.Compile(valTok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", valTok.text, err)
+		}
+		cmp.re = re
+	}
+
+	// Ordering a list only makes sense against a count
+	if _, isList := field.Value(&core.Skill{}).([]string); isList && isOrdering(op) && cmp.number == nil {
+		return nil, fmt.Errorf("'%s' is a list; '%s' needs a number to compare its length against", field.Name, op)
+	}
+
+	return cmp, nil
+}
+
+func isKeywordOp(word string) bool {
+	switch strings.ToLower(word) {
+	case "has", "contains", "matches":
+		return true
+	}
+	return false
+}
+
+func isOrdering(op string) bool {
+	switch op {
+	case "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// ============== Evaluation ==============
+
+type andExpr struct{ left, right Expr }
+
+func (e *andExpr) Match(s *core.Skill) bool { return e.left.Match(s) && e.right.Match(s) }
+func (e *andExpr) String() string           { return fmt.Sprintf("(%s and %s)", e.left, e.right) }
+
+type orExpr struct{ left, right Expr }
+
+func (e *orExpr) Match(s *core.Skill) bool { return e.left.Match(s) || e.right.Match(s) }
+func (e *orExpr) String() string           { return fmt.Sprintf("(%s or %s)", e.left, e.right) }
+
+type notExpr struct{ inner Expr }
+
+func (e *notExpr) Match(s *core.Skill) bool { return !e.inner.Match(s) }
+func (e *notExpr) String() string           { return fmt.Sprintf("not %s", e.inner) }
+
+type comparison struct {
+	field    Field
+	op       string
+	value    string
+	number   *int
+	re       *regexp.Regexp
+	tagMatch func(tag, want string) bool
+}
+
+func (c *comparison) String() string {
+	return fmt.Sprintf("%s %s %q", c.field.Name, c.op, c.value)
+}
+
+func (c *comparison) Match(s *core.Skill) bool {
+	switch v := c.field.Value(s).(type) {
+	case []string:
+		return c.matchList(v)
+	case string:
+		return c.matchString(v)
+	}
+	return false
+}
+
+func (c *comparison) matchList(items []string) bool {
+	if c.number != nil && c.op != "has" && c.op != "contains" && c.op != "matches" {
+		return compareOrdered(compareInts(len(items), *c.number), c.op)
+	}
+
+	found := false
+	for _, item := range items {
+		if c.matchItem(item) {
+			found = true
+			break
+		}
+	}
+	if c.op == "!=" {
+		return !found
+	}
+	return found
+}
+
+func (c *comparison) matchItem(item string) bool {
+	switch c.op {
+	case "contains":
+		return strings.Contains(strings.ToLower(item), strings.ToLower(c.value))
+	case "matches":
+		return c.re.MatchString(item)
+	default: // =, !=, has
+		if c.tagMatch != nil {
+			return c.tagMatch(item, c.value)
+		}
+		return strings.EqualFold(item, c.value)
+	}
+}
+
+func (c *comparison) matchString(v string) bool {
+	switch c.op {
+	case "=", "has":
+		return strings.EqualFold(v, c.value)
+	case "!=":
+		return !strings.EqualFold(v, c.value)
+	case "contains":
+		return strings.Contains(strings.ToLower(v), strings.ToLower(c.value))
+	case "matches":
+		return c.re.MatchString(v)
+	}
+	return compareOrdered(compareScalars(v, c.value), c.op)
+}
+
+func compareOrdered(c int, op string) bool {
+	switch op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// compareScalars compares two strings numerically when both look like
+// dotted version numbers (1.10 > 1.9), and case-insensitively otherwise
+func compareScalars(a, b string) int {
+	av, aok := parseVersion(a)
+	bv, bok := parseVersion(b)
+	if !aok || !bok {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	for i := 0; i < len(av) || i < len(bv); i++ {
+		var x, y int
+		if i < len(av) {
+			x = av[i]
+		}
+		if i < len(bv) {
+			y = bv[i]
+		}
+		if c := compareInts(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -0,0 +1,250 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"openskill/pkg/core"
+)
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []token
+		wantErr string
+	}{
+		{
+			input: "",
+			want:  []token{{kind: tokEOF, pos: 0}},
+		},
+		{
+			input: "tags has go",
+			want: []token{
+				{tokWord, "tags", 0}, {tokWord, "has", 5}, {tokWord, "go", 9}, {kind: tokEOF, pos: 11},
+			},
+		},
+		{
+			input: "rules>=3&&!(name=='a b')",
+			want: []token{
+				{tokWord, "rules", 0}, {tokOp, ">=", 5}, {tokWord, "3", 7}, {tokWord, "and", 8},
+				{tokWord, "not", 10}, {tokLParen, "(", 11}, {tokWord, "name", 12}, {tokOp, "==", 16},
+				{tokString, "a b", 18}, {tokRParen, ")", 23}, {kind: tokEOF, pos: 24},
+			},
+		},
+		{
+			input: `a || b ~ "x\"y"`,
+			want: []token{
+				{tokWord, "a", 0}, {tokWord, "or", 2}, {tokWord, "b", 5}, {tokOp, "~", 7},
+				{tokString, `x"y`, 9}, {kind: tokEOF, pos: 15},
+			},
+		},
+		{
+			input: "version<v1.2.0",
+			want: []token{
+				{tokWord, "version", 0}, {tokOp, "<", 7}, {tokWord, "v1.2.0", 8}, {kind: tokEOF, pos: 14},
+			},
+		},
+		{
+			// Positions count runes, not bytes
+			input: "名前 = x",
+			want: []token{
+				{tokWord, "名前", 0}, {tokOp, "=", 3}, {tokWord, "x", 5}, {kind: tokEOF, pos: 6},
+			},
+		},
+		{input: "name = 'open", wantErr: "unterminated string starting at position 8"},
+		{input: "a & b", wantErr: "unexpected '&' at position 3"},
+		{input: "a | b", wantErr: "unexpected '|' at position 3"},
+		{input: "name = $x", wantErr: "unexpected character '$' at position 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := lex(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("lex() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lex() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string // The parsed expression's String()
+		wantErr string
+	}{
+		{input: "name = review", want: `name = "review"`},
+		{input: "NAME == review", want: `name = "review"`},
+		{input: "tags HAS go", want: `tags has "go"`},
+		{input: "description ~ '^Re'", want: `description matches "^Re"`},
+		{input: "owner = me", wantErr: "unknown field 'owner'"},
+		{input: "name = a or name = b and group = c", want: `(name = "a" or (name = "b" and group = "c"))`},
+		{input: "(name = a or name = b) and group = c", want: `((name = "a" or name = "b") and group = "c")`},
+		{input: "not not name = a", want: `not not name = "a"`},
+		{input: "!name = a && rules > 2", want: `(not name = "a" and rules > "2")`},
+		{input: "tags >= 2", want: `tags >= "2"`},
+		{input: "", wantErr: "expected field name but found end of expression at position 1"},
+		{input: "name", wantErr: "expected operator after 'name' but found end of expression at position 5"},
+		{input: "name review", wantErr: "expected operator after 'name' but found 'review' at position 6"},
+		{input: "name =", wantErr: "expected value after '=' but found end of expression at position 7"},
+		{input: "name = a name = b", wantErr: "unexpected 'name' at position 10"},
+		{input: "(name = a", wantErr: "expected ')' but found end of expression at position 10"},
+		{input: "name = a)", wantErr: "unexpected ')' at position 9"},
+		{input: "name matches '('", wantErr: `invalid regular expression "("`},
+		{input: "tags > go", wantErr: "'tags' is a list; '>' needs a number to compare its length against"},
+		{input: "rules < '3'", wantErr: "'rules' is a list; '<' needs a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Errorf("Parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+var testSkills = []core.Skill{
+	{Name: "code-review", Description: "Reviews Go code", Tags: []string{"go", "review"}, Rules: []string{"a", "b", "c"}, Version: "1.10.0", Group: "dev"},
+	{Name: "commit-msg", Description: "Writes commit messages", Tags: []string{"git"}, Rules: []string{"a"}, Version: "1.9.2"},
+	{Name: "Docs", Description: "Explains code", Tags: []string{"Writing", "lang/en"}, Version: "v2", Group: "writing"},
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"name = CODE-REVIEW", []string{"code-review"}},
+		{"name != code-review", []string{"commit-msg", "Docs"}},
+		{"name contains MIT", []string{"commit-msg"}},
+		{"name matches '^c'", []string{"code-review", "commit-msg"}},
+		{"description contains code", []string{"code-review", "Docs"}},
+		{"tags has writing", []string{"Docs"}},
+		{"tags = go", []string{"code-review"}},
+		{"tags != go", []string{"commit-msg", "Docs"}},
+		{"tags contains lang", []string{"Docs"}},
+		{"tags matches '^g'", []string{"code-review", "commit-msg"}},
+		{"rules >= 1", []string{"code-review", "commit-msg"}},
+		{"rules = 0", []string{"Docs"}},
+		{"rules > 2", []string{"code-review"}},
+		{"rules has b", []string{"code-review"}},
+		// Versions order numerically, so 1.10 is after 1.9; = still compares text
+		{"version > 1.9", []string{"code-review", "commit-msg", "Docs"}},
+		{"version > 1.9.2", []string{"code-review", "Docs"}},
+		{"version < v1.10", []string{"commit-msg"}},
+		{"version >= 2.0", []string{"Docs"}},
+		{"version = 2.0", nil},
+		{"group = ''", []string{"commit-msg"}},
+		{"group < e", []string{"code-review", "commit-msg"}},
+		{"not (group = dev or group = writing)", []string{"commit-msg"}},
+		{"tags has go or rules = 0 and group = writing", []string{"code-review", "Docs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := skillNames(Filter(testSkills, expr)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithTagMatch(t *testing.T) {
+	opts := Options{TagMatch: func(tag, want string) bool {
+		return strings.EqualFold(tag, want) || strings.HasPrefix(strings.ToLower(tag), strings.ToLower(want)+"/")
+	}}
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"tags has lang", []string{"Docs"}},
+		{"tags != lang", []string{"code-review", "commit-msg"}},
+		// TagMatch only applies to tags
+		{"name = code", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseWith(tt.expr, opts)
+			if err != nil {
+				t.Fatalf("ParseWith() error = %v", err)
+			}
+			if got := skillNames(Filter(testSkills, expr)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// --sort orders versions the way --where compares them
+func TestSortAgreesWithFilter(t *testing.T) {
+	list := append([]core.Skill(nil), testSkills...)
+	keys, err := ParseSort("version")
+	if err != nil {
+		t.Fatalf("ParseSort() error = %v", err)
+	}
+	Sort(list, keys)
+	if got, want := skillNames(list), []string{"commit-msg", "code-review", "Docs"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sort() = %v, want %v", got, want)
+	}
+
+	// Everything after a skill in version order is greater than it
+	for i := range list {
+		expr, err := Parse("version > '" + list[i].Version + "'")
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if got, want := skillNames(Filter(list, expr)), skillNames(list[i+1:]); !reflect.DeepEqual(got, want) {
+			t.Errorf("version > %s = %v, want %v", list[i].Version, got, want)
+		}
+	}
+}
+
+func TestCompareScalars(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10", "1.9", 1},
+		{"1.2", "1.2.0", 0},
+		{"v2", "2.0.0", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"abc", "ABD", -1},
+		{"1.x", "1.9", 1},
+		{"", "1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareScalars(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareScalars(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func skillNames(list []core.Skill) []string {
+	var names []string
+	for _, s := range list {
+		names = append(names, s.Name)
+	}
+	return names
+}
@@ -0,0 +1,203 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"openskill/pkg/core"
+)
+
+// MaxItemBytes caps each piece of gathered context
+const MaxItemBytes = 32 * 1024
+
+// commandTimeout bounds each context command and URL fetch
+const commandTimeout = 30 * time.Second
+
+// Item kinds in a Bundle
+const (
+	KindFile    = "file"
+	KindCommand = "command"
+	KindURL     = "url"
+	KindEnv     = "env"
+	KindText    = "text" // Supplied directly, e.g. by a test case
+)
+
+// Item is one piece of gathered context
+type Item struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Source    string `json:"source" yaml:"source"` // Path, command, URL or variable name
+	Content   string `json:"content" yaml:"content"`
+	Truncated bool   `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// Bundle is the context a skill gathers before it runs
+type Bundle struct {
+	Items   []Item   `json:"items" yaml:"items"`
+	Skipped []string `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Sources left out, with the reason
+}
+
+// GatherOptions controls what Gather may do
+type GatherOptions struct {
+	Dir string // Directory files and commands are relative to; "" is the working directory
+
+	// Exec allows running the skill's context commands and fetching its
+	// URLs. Without it they are skipped, since a SKILL.md from elsewhere
+	// could run anything.
+	Exec bool
+}
+
+// Gather collects the files, glob matches, environment variables and,
+// when allowed, command output and URLs a skill's context asks for.
+// Sources that can't be read are recorded in Skipped.
+func Gather(ctx context.Context, cfg *core.ContextConfig, opts GatherOptions) *Bundle {
+	b := &Bundle{}
+	if cfg == nil {
+		return b
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	seen := map[string]bool{}
+	addFile := func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		data, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			b.skip(path, err)
+			return
+		}
+		b.add(KindFile, path, string(data))
+	}
+
+	for _, path := range cfg.Files {
+		addFile(path)
+	}
+	for _, pattern := range cfg.Globs {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			b.skip(pattern, err)
+			continue
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || info.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				rel = match
+			}
+			addFile(rel)
+		}
+	}
+	for _, name := range cfg.Environment {
+		if value, ok := os.LookupEnv(name); ok {
+			b.add(KindEnv, name, value)
+		} else {
+			b.skip(name, fmt.Errorf("not set"))
+		}
+	}
+
+	for _, command := range cfg.Commands {
+		if !opts.Exec {
+			b.skip(command, fmt.Errorf("commands need --exec"))
+			continue
+		}
+		out, err := runCommand(ctx, dir, command)
+		if err != nil {
+			b.skip(command, err)
+			continue
+		}
+		b.add(KindCommand, command, out)
+	}
+	for _, url := range cfg.URLs {
+		if !opts.Exec {
+			b.skip(url, fmt.Errorf("URLs need --exec"))
+			continue
+		}
+		body, err := fetch(ctx, url)
+		if err != nil {
+			b.skip(url, err)
+			continue
+		}
+		b.add(KindURL, url, body)
+	}
+	return b
+}
+
+func (b *Bundle) add(kind, source, content string) {
+	item := Item{Kind: kind, Source: source, Content: content}
+	if len(item.Content) > MaxItemBytes {
+		item.Content = item.Content[:MaxItemBytes]
+		item.Truncated = true
+	}
+	b.Items = append(b.Items, item)
+}
+
+func (b *Bundle) skip(source string, err error) {
+	b.Skipped = append(b.Skipped, fmt.Sprintf("%s: %v", source, err))
+}
+
+// String formats the bundle for a system prompt, each item in a tag
+// naming its source
+func (b *Bundle) String() string {
+	var sb strings.Builder
+	sb.WriteString("Context:\n")
+	for _, item := range b.Items {
+		fmt.Fprintf(&sb, "\n<context %s=%q>\n%s", item.Kind, item.Source, item.Content)
+		if !strings.HasSuffix(item.Content, "\n") {
+			sb.WriteString("\n")
+		}
+		if item.Truncated {
+			sb.WriteString("[truncated]\n")
+		}
+		sb.WriteString("</context>\n")
+	}
+	return sb.String()
+}
+
+func runCommand(ctx context.Context, dir, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func fetch(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxItemBytes+1))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,132 @@
+// Package render compiles a skill into the messages sent to an AI provider
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"openskill/pkg/core"
+	"openskill/pkg/llm"
+)
+
+// Input is everything a skill is compiled with
+type Input struct {
+	Skill   *core.Skill       // Resolved skill; see skills.Manager.Resolve
+	Context *Bundle           // Gathered context, or nil
+	Vars    map[string]string // Override the skill's variables
+	Prompt  string            // User input, sent verbatim; empty leaves the request without a final user turn
+}
+
+// Compile turns a skill into a provider-neutral request: the skill and its
+// context become the system prompt, each example a user/assistant pair,
+// and the prompt the last user turn. {{name}} placeholders in the
+// description, rules and examples are replaced by variables; the prompt is
+// the user's own text and is passed through untouched.
+// Providers then place the system prompt the way their API expects; see
+// llm.Payload.
+func Compile(in Input) (llm.Request, error) {
+	vars := Variables(in.Skill, in.Vars)
+	missing := map[string]bool{}
+	expand := func(text string) string {
+		return expandVars(text, vars, missing)
+	}
+
+	skill := in.Skill
+	var system strings.Builder
+	fmt.Fprintf(&system, "You are operating with the '%s' skill.\n\n", skill.Name)
+	fmt.Fprintf(&system, "Description: %s\n\n", expand(skill.Description))
+	if len(skill.Rules) > 0 {
+		system.WriteString("Rules you must follow:\n")
+		for i, rule := range skill.Rules {
+			fmt.Fprintf(&system, "%d. %s\n", i+1, expand(rule))
+		}
+	}
+	if skill.OutputFormat != "" {
+		fmt.Fprintf(&system, "\nRespond in %s.\n", skill.OutputFormat)
+	}
+	if in.Context != nil && len(in.Context.Items) > 0 {
+		system.WriteString("\n")
+		system.WriteString(in.Context.String())
+	}
+
+	req := llm.Request{System: system.String()}
+	for _, ex := range skill.Examples {
+		req.Messages = append(req.Messages,
+			llm.Message{Role: llm.RoleUser, Content: expand(ex.Input)},
+			llm.Message{Role: llm.RoleAssistant, Content: expand(ex.Output)})
+	}
+	if in.Prompt != "" {
+		req.Messages = append(req.Messages, llm.Message{Role: llm.RoleUser, Content: in.Prompt})
+	}
+
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return llm.Request{}, &MissingVarsError{Names: names}
+	}
+	return req, nil
+}
+
+// placeholder matches {{name}}, allowing spaces inside the braces
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// Expand replaces {{name}} placeholders with variables, leaving those
+// without a value as they are
+func Expand(text string, vars map[string]string) string {
+	return expandVars(text, vars, nil)
+}
+
+func expandVars(text string, vars map[string]string, missing map[string]bool) string {
+	return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			if missing != nil {
+				missing[name] = true
+			}
+			return match
+		}
+		return value
+	})
+}
+
+// Variables merges a skill's declared variables with overrides
+func Variables(skill *core.Skill, overrides map[string]string) map[string]string {
+	vars := make(map[string]string, len(skill.Variables)+len(overrides))
+	for k, v := range skill.Variables {
+		vars[k] = v
+	}
+	for k, v := range overrides {
+		vars[k] = v
+	}
+	return vars
+}
+
+// ParseVars parses name=value assignments
+func ParseVars(assignments []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, a := range assignments {
+		name, value, ok := strings.Cut(a, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable %q (expected name=value)", a)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// MissingVarsError reports placeholders with no value
+type MissingVarsError struct {
+	Names []string
+}
+
+func (e *MissingVarsError) Error() string {
+	return fmt.Sprintf("no value for variable(s) %s; set them with --var name=value or under variables in SKILL.md",
+		strings.Join(e.Names, ", "))
+}
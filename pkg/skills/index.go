@@ -0,0 +1,300 @@
+package skills
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"openskill/pkg/core"
+)
+
+// indexVersion is bumped whenever the on-disk index layout changes
+const indexVersion = 2
+
+// skillIndex caches parsed SKILL.md files between runs so that listing
+// a large library does not re-parse every skill on each invocation.
+type skillIndex struct {
+	Version int                    `json:"version"`
+	Entries map[string]*indexEntry `json:"entries"` // Keyed by skill directory name
+
+	dirty bool
+}
+
+// indexEntry records what was parsed from a single SKILL.md
+type indexEntry struct {
+	Path    string      `json:"path"`
+	ModTime time.Time   `json:"mod_time"`
+	Size    int64       `json:"size"`
+	Hash    string      `json:"hash"`
+	Skill   *core.Skill `json:"skill,omitempty"`
+	Err     string      `json:"error,omitempty"` // Parse error, cached so broken files aren't re-read
+}
+
+// loadIndex reads the index file, returning an empty index if it is
+// missing, unreadable or from an older layout
+func loadIndex(path string) *skillIndex {
+	idx := &skillIndex{Version: indexVersion, Entries: make(map[string]*indexEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return idx
+	}
+
+	var stored skillIndex
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != indexVersion || stored.Entries == nil {
+		idx.dirty = true
+		return idx
+	}
+	return &stored
+}
+
+// save writes the index back to disk if anything changed
+func (idx *skillIndex) save(path string) error {
+	if !idx.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so a concurrent reader never sees a partial index;
+	// each writer gets its own temp file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".index-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	idx.dirty = false
+	return nil
+}
+
+// skills returns copies of all successfully parsed skills, ordered by directory name
+func (idx *skillIndex) skills() []core.Skill {
+	names := make([]string, 0, len(idx.Entries))
+	for name, entry := range idx.Entries {
+		if entry.Skill != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	skills := make([]core.Skill, 0, len(names))
+	for _, name := range names {
+		skills = append(skills, *cloneSkill(idx.Entries[name].Skill))
+	}
+	return skills
+}
+
+// refreshIndex brings the index up to date with the skills directory.
+// Entries whose mtime and size are unchanged are reused as-is; changed
+// files are re-hashed and only re-parsed when their content differs.
+func (m *Manager) refreshIndex() error {
+	if m.index == nil {
+		m.index = loadIndex(m.indexPath)
+	}
+	if m.fresh {
+		return nil
+	}
+
+	if _, err := os.Stat(m.baseDir); os.IsNotExist(err) {
+		if len(m.index.Entries) > 0 {
+			m.index.Entries = make(map[string]*indexEntry)
+			m.index.dirty = true
+		}
+		m.fresh = true
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(m.baseDir)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var stale []string
+	for _, entry := range dirEntries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		// Skip history directory
+		if entry.Name() == ".history" {
+			continue
+		}
+
+		// Check if SKILL.md exists in the directory
+		skillPath := filepath.Join(m.baseDir, entry.Name(), "SKILL.md")
+		info, err := os.Stat(skillPath)
+		if err != nil {
+			continue
+		}
+		seen[entry.Name()] = true
+
+		cached, ok := m.index.Entries[entry.Name()]
+		if ok && cached.ModTime.Equal(info.ModTime()) && cached.Size == info.Size() {
+			continue
+		}
+		stale = append(stale, entry.Name())
+	}
+
+	for name := range m.index.Entries {
+		if !seen[name] {
+			delete(m.index.Entries, name)
+			m.index.dirty = true
+		}
+	}
+
+	for name, entry := range m.parseEntries(stale) {
+		if entry == nil {
+			continue
+		}
+		m.index.Entries[name] = entry
+		m.index.dirty = true
+	}
+
+	m.fresh = true
+
+	// The index is only a cache; failing to persist it is not an error
+	_ = m.index.save(m.indexPath)
+	return nil
+}
+
+// parseEntries reads and parses the given skill directories in parallel
+func (m *Manager) parseEntries(names []string) map[string]*indexEntry {
+	results := make(map[string]*indexEntry, len(names))
+	if len(names) == 0 {
+		return results
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				entry := m.parseEntry(name)
+				mu.Lock()
+				results[name] = entry
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// parseEntry builds a fresh index entry for one skill directory, reusing
+// the previously parsed skill when only the mtime changed
+func (m *Manager) parseEntry(name string) *indexEntry {
+	path := filepath.Join(m.baseDir, name, "SKILL.md")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	entry := &indexEntry{
+		Path:    path,
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Hash:    hex.EncodeToString(sum[:]),
+	}
+
+	// Entries are only read here, never written, so concurrent access is safe
+	if cached, ok := m.index.Entries[name]; ok && cached.Hash == entry.Hash {
+		entry.Skill = cached.Skill
+		entry.Err = cached.Err
+		return entry
+	}
+
+	skill, err := ParseSkill(data)
+	if err != nil {
+		entry.Err = err.Error()
+	} else {
+		entry.Skill = skill
+	}
+	return entry
+}
+
+// forget drops a skill from the index so its next lookup re-reads disk
+func (m *Manager) forget(name string) {
+	m.fresh = false
+	if m.index == nil {
+		return
+	}
+	key := filepath.Base(m.skillDir(name))
+	if _, ok := m.index.Entries[key]; ok {
+		delete(m.index.Entries, key)
+		m.index.dirty = true
+	}
+}
+
+// cloneSkill returns a copy of a skill that shares no slices or maps with the original
+func cloneSkill(s *core.Skill) *core.Skill {
+	c := *s
+	c.Rules = append([]string(nil), s.Rules...)
+	c.Includes = append([]string(nil), s.Includes...)
+	c.Tags = append([]string(nil), s.Tags...)
+	c.Chain = append([]string(nil), s.Chain...)
+	c.Examples = append([]core.Example(nil), s.Examples...)
+	if s.Variables != nil {
+		c.Variables = make(map[string]string, len(s.Variables))
+		for k, v := range s.Variables {
+			c.Variables[k] = v
+		}
+	}
+	if s.Context != nil {
+		ctx := *s.Context
+		c.Context = &ctx
+	}
+	if s.Hooks != nil {
+		hooks := *s.Hooks
+		c.Hooks = &hooks
+	}
+	return &c
+}
@@ -0,0 +1,240 @@
+package skills
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newIndexManager returns a manager rooted in a temp dir
+func newIndexManager(t *testing.T) *Manager {
+	dir := t.TempDir()
+	return &Manager{
+		baseDir:   filepath.Join(dir, "skills"),
+		indexPath: filepath.Join(dir, "index.json"),
+	}
+}
+
+func writeIndexSkill(t *testing.T, m *Manager, name, description string, mtime time.Time) string {
+	t.Helper()
+	path := filepath.Join(m.baseDir, name, "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("---\nname: " + name + "\ndescription: " + description + "\n---\n\n# " + name + "\n")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// reload refreshes the index as a new process would
+func reload(t *testing.T, m *Manager) map[string]string {
+	t.Helper()
+	m.index, m.fresh = nil, false
+	list, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	descriptions := map[string]string{}
+	for _, s := range list {
+		descriptions[s.Name] = s.Description
+	}
+	return descriptions
+}
+
+func TestIndexInvalidation(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name        string
+		description string    // Rewritten SKILL.md description
+		mtime       time.Time // Rewritten SKILL.md mtime
+		poison      bool      // Change the cached skill to tell a reuse from a re-parse
+		want        string
+	}{
+		{
+			name:        "same mtime and size reuses the entry without reading",
+			description: "one",
+			mtime:       base,
+			poison:      true,
+			want:        "cached",
+		},
+		{
+			name:        "changed mtime with the same content reuses the parsed skill",
+			description: "one",
+			mtime:       base.Add(time.Hour),
+			poison:      true,
+			want:        "cached",
+		},
+		{
+			name:        "same mtime and size with new content is not noticed",
+			description: "two",
+			mtime:       base,
+			want:        "one",
+		},
+		{
+			name:        "changed mtime and content is re-parsed",
+			description: "two",
+			mtime:       base.Add(time.Hour),
+			poison:      true,
+			want:        "two",
+		},
+		{
+			name:        "changed size is re-parsed",
+			description: "three!",
+			mtime:       base,
+			poison:      true,
+			want:        "three!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newIndexManager(t)
+			writeIndexSkill(t, m, "demo", "one", base)
+			if got := reload(t, m)["demo"]; got != "one" {
+				t.Fatalf("first load description = %q, want %q", got, "one")
+			}
+			if _, err := os.Stat(m.indexPath); err != nil {
+				t.Fatalf("index was not saved: %v", err)
+			}
+			if tt.poison {
+				m.index.Entries["demo"].Skill.Description = "cached"
+				m.index.dirty = true
+				if err := m.index.save(m.indexPath); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			writeIndexSkill(t, m, "demo", tt.description, tt.mtime)
+			if got := reload(t, m)["demo"]; got != tt.want {
+				t.Errorf("description = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexDropsRemovedSkills(t *testing.T) {
+	m := newIndexManager(t)
+	now := time.Now()
+	writeIndexSkill(t, m, "a", "a", now)
+	writeIndexSkill(t, m, "b", "b", now)
+	if got := reload(t, m); len(got) != 2 {
+		t.Fatalf("skills = %v, want a and b", got)
+	}
+
+	if err := os.RemoveAll(filepath.Join(m.baseDir, "a")); err != nil {
+		t.Fatal(err)
+	}
+	got := reload(t, m)
+	if _, ok := got["a"]; ok || len(got) != 1 {
+		t.Errorf("skills = %v, want only b", got)
+	}
+	if _, ok := loadIndex(m.indexPath).Entries["a"]; ok {
+		t.Errorf("saved index still has a removed skill")
+	}
+}
+
+func TestIndexForget(t *testing.T) {
+	m := newIndexManager(t)
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	writeIndexSkill(t, m, "demo", "one", base)
+	reload(t, m)
+
+	// Same size and mtime, so only forget makes the change visible
+	writeIndexSkill(t, m, "demo", "two", base)
+	m.forget("Demo")
+	if _, ok := m.index.Entries["demo"]; ok {
+		t.Fatalf("forget() kept the entry")
+	}
+	if m.fresh || !m.index.dirty {
+		t.Errorf("forget() left fresh = %v, dirty = %v", m.fresh, m.index.dirty)
+	}
+	list, err := m.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].Description != "two" {
+		t.Errorf("List() = %+v, want demo re-read from disk", list)
+	}
+
+	// Forgetting an unknown skill leaves the index clean
+	m.index.dirty = false
+	m.forget("missing")
+	if m.index.dirty {
+		t.Errorf("forget() of an unknown skill dirtied the index")
+	}
+}
+
+func TestIndexSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "index.json")
+
+	idx := loadIndex(path)
+	if err := idx.save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("a clean index was written")
+	}
+
+	idx.Entries["x"] = &indexEntry{Path: "x/SKILL.md", Hash: "abc"}
+	idx.dirty = true
+	if err := idx.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	if idx.dirty {
+		t.Errorf("save() left the index dirty")
+	}
+	if got := loadIndex(path).Entries["x"]; got == nil || got.Hash != "abc" {
+		t.Errorf("reloaded entry = %+v, want hash abc", got)
+	}
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("save() left temp files behind: %v", files)
+	}
+
+	for _, content := range []string{"not json", `{"version": 1, "entries": {}}`} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if idx := loadIndex(path); len(idx.Entries) != 0 || !idx.dirty {
+			t.Errorf("loadIndex(%q) = %+v, want an empty dirty index", content, idx)
+		}
+	}
+}
+
+func TestIndexConcurrentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			idx := loadIndex(path)
+			idx.Entries = map[string]*indexEntry{}
+			for j := 0; j < 200; j++ {
+				idx.Entries[strconv.Itoa(j)] = &indexEntry{Hash: strings.Repeat(strconv.Itoa(i), 64)}
+			}
+			idx.dirty = true
+			if err := idx.save(path); err != nil {
+				t.Errorf("save() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Whichever writer won, the index is whole
+	if idx := loadIndex(path); idx.dirty || len(idx.Entries) != 200 {
+		t.Errorf("index has %d entries (dirty = %v), want 200 from one writer", len(idx.Entries), idx.dirty)
+	}
+}
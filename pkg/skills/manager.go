@@ -3,6 +3,7 @@ package skills
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,15 +21,20 @@ const TemplatesDir = ".claude/templates"
 const GroupsDir = ".claude/groups"
 const WorkspaceFile = ".claude/workspace.yaml"
 const HistoryDir = ".claude/skills/.history"
+const IndexFile = ".claude/.skill-index.json"
 
 // Manager handles skill CRUD operations
 type Manager struct {
-	baseDir string
+	baseDir   string
+	indexPath string
+	index     *skillIndex       // lazily loaded metadata index
+	fresh     bool              // index has been checked against disk in this process
+	tags      *core.TagRegistry // lazily loaded tag registry
 }
 
 // NewManager creates a new skill manager
 func NewManager() *Manager {
-	return &Manager{baseDir: SkillsDir}
+	return &Manager{baseDir: SkillsDir, indexPath: IndexFile}
 }
 
 // ensureDir creates the skills directory if it doesn't exist
@@ -68,43 +74,28 @@ func (m *Manager) Add(skill *core.Skill) error {
 
 // List returns all skills
 func (m *Manager) List() ([]core.Skill, error) {
-	if _, err := os.Stat(m.baseDir); os.IsNotExist(err) {
-		return []core.Skill{}, nil
+	if err := m.refreshIndex(); err != nil {
+		return nil, err
 	}
+	return m.index.skills(), nil
+}
 
-	entries, err := os.ReadDir(m.baseDir)
-	if err != nil {
+// Dirs returns the directory name of every skill, including skills whose
+// SKILL.md fails to parse
+func (m *Manager) Dirs() ([]string, error) {
+	if err := m.refreshIndex(); err != nil {
 		return nil, err
 	}
-
-	var skills []core.Skill
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		// Skip history directory
-		if entry.Name() == ".history" {
-			continue
-		}
-
-		// Check if SKILL.md exists in the directory
-		skillPath := filepath.Join(m.baseDir, entry.Name(), "SKILL.md")
-		if _, err := os.Stat(skillPath); os.IsNotExist(err) {
-			continue
-		}
-
-		skill, err := m.load(entry.Name())
-		if err != nil {
-			continue
-		}
-		skills = append(skills, *skill)
+	dirs := make([]string, 0, len(m.index.Entries))
+	for name := range m.index.Entries {
+		dirs = append(dirs, name)
 	}
-
-	return skills, nil
+	sort.Strings(dirs)
+	return dirs, nil
 }
 
-// ListByTag returns all skills with the given tag
+// ListByTag returns all skills with the given tag. Aliases are resolved
+// and a parent tag also matches its children ("lang" matches "lang/go").
 func (m *Manager) ListByTag(tag string) ([]core.Skill, error) {
 	allSkills, err := m.List()
 	if err != nil {
@@ -114,7 +105,7 @@ func (m *Manager) ListByTag(tag string) ([]core.Skill, error) {
 	var filtered []core.Skill
 	for _, skill := range allSkills {
 		for _, t := range skill.Tags {
-			if strings.EqualFold(t, tag) {
+			if m.TagMatches(t, tag) {
 				filtered = append(filtered, skill)
 				break
 			}
@@ -141,27 +132,14 @@ func (m *Manager) ListByGroup(group string) ([]core.Skill, error) {
 	return filtered, nil
 }
 
-// GetAllTags returns all unique tags used across skills
+// GetAllTags returns all unique canonical tags used across skills,
+// including the parents of hierarchical tags
 func (m *Manager) GetAllTags() ([]string, error) {
-	skills, err := m.List()
+	counts, err := m.TagCounts()
 	if err != nil {
 		return nil, err
 	}
-
-	tagSet := make(map[string]bool)
-	for _, skill := range skills {
-		for _, tag := range skill.Tags {
-			tagSet[strings.ToLower(tag)] = true
-		}
-	}
-
-	var tags []string
-	for tag := range tagSet {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
-
-	return tags, nil
+	return sortedKeys(counts), nil
 }
 
 // GetAllGroups returns all unique groups used across skills
@@ -189,7 +167,24 @@ func (m *Manager) GetAllGroups() ([]string, error) {
 
 // Get retrieves a skill by name
 func (m *Manager) Get(name string) (*core.Skill, error) {
-	return m.load(name)
+	if err := m.refreshIndex(); err != nil {
+		return nil, err
+	}
+	entry, ok := m.index.Entries[filepath.Base(m.skillDir(name))]
+	if !ok {
+		// Not indexed; let load report the underlying error
+		return m.load(name)
+	}
+	if entry.Err != "" {
+		return nil, errors.New(entry.Err)
+	}
+	return cloneSkill(entry.Skill), nil
+}
+
+// Exists reports whether a skill has a SKILL.md
+func (m *Manager) Exists(name string) bool {
+	_, err := os.Stat(m.skillPath(name))
+	return err == nil
 }
 
 // Edit updates an existing skill
@@ -205,6 +200,7 @@ func (m *Manager) Edit(name string, skill *core.Skill) error {
 		if err := os.Rename(dir, newDir); err != nil {
 			return fmt.Errorf("failed to rename skill: %w", err)
 		}
+		m.forget(name)
 	}
 
 	return m.save(skill)
@@ -216,6 +212,7 @@ func (m *Manager) Remove(name string) error {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return fmt.Errorf("skill '%s' not found", name)
 	}
+	m.forget(name)
 	return os.RemoveAll(dir)
 }
 
@@ -243,6 +240,7 @@ func (m *Manager) save(skill *core.Skill) error {
 		Context      *core.ContextConfig `yaml:"context,omitempty"`
 		Hooks        *core.HooksConfig   `yaml:"hooks,omitempty"`
 		Chain        []string          `yaml:"chain,omitempty"`
+		Examples     []core.Example    `yaml:"examples,omitempty"`
 	}{
 		Name:         skill.Name,
 		Description:  skill.Description,
@@ -258,6 +256,7 @@ func (m *Manager) save(skill *core.Skill) error {
 		Context:      skill.Context,
 		Hooks:        skill.Hooks,
 		Chain:        skill.Chain,
+		Examples:     skill.Examples,
 	}
 	fm, err := yaml.Marshal(frontmatter)
 	if err != nil {
@@ -277,6 +276,7 @@ func (m *Manager) save(skill *core.Skill) error {
 		}
 	}
 
+	m.forget(skill.Name)
 	return os.WriteFile(path, []byte(content.String()), 0644)
 }
 
@@ -287,7 +287,11 @@ func (m *Manager) load(name string) (*core.Skill, error) {
 	if err != nil {
 		return nil, err
 	}
+	return ParseSkill(data)
+}
 
+// ParseSkill parses the contents of a SKILL.md file
+func ParseSkill(data []byte) (*core.Skill, error) {
 	content := string(data)
 
 	// Parse YAML frontmatter
@@ -320,6 +324,7 @@ func (m *Manager) load(name string) (*core.Skill, error) {
 		Context      *core.ContextConfig `yaml:"context,omitempty"`
 		Hooks        *core.HooksConfig   `yaml:"hooks,omitempty"`
 		Chain        []string          `yaml:"chain,omitempty"`
+		Examples     []core.Example    `yaml:"examples,omitempty"`
 	}
 	if err := yaml.Unmarshal([]byte(frontmatterStr), &frontmatter); err != nil {
 		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
@@ -344,6 +349,7 @@ func (m *Manager) load(name string) (*core.Skill, error) {
 		Context:      frontmatter.Context,
 		Hooks:        frontmatter.Hooks,
 		Chain:        frontmatter.Chain,
+		Examples:     frontmatter.Examples,
 	}, nil
 }
 
@@ -495,16 +501,36 @@ func (m *Manager) Rollback(name string, version int) error {
 		return err
 	}
 
-	content := string(data)
-	// Skip the timestamp comment line
+	// Write to current skill file
+	m.forget(name)
+	return os.WriteFile(m.skillPath(name), []byte(stripVersionHeader(string(data))), 0644)
+}
+
+// GetVersion parses a version saved in a skill's history
+func (m *Manager) GetVersion(name string, version int) (*core.Skill, error) {
+	historyPath := filepath.Join(HistoryDir, strings.ToLower(name))
+	data, err := os.ReadFile(filepath.Join(historyPath, fmt.Sprintf("SKILL.v%d.md", version)))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("version %d not found for skill '%s'", version, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	skill, err := ParseSkill([]byte(stripVersionHeader(string(data))))
+	if err != nil {
+		return nil, fmt.Errorf("version %d of '%s': %w", version, name, err)
+	}
+	return skill, nil
+}
+
+// stripVersionHeader removes the timestamp comment SaveVersion adds
+func stripVersionHeader(content string) string {
 	if strings.HasPrefix(content, "<!--") {
 		if idx := strings.Index(content, "-->\n"); idx != -1 {
 			content = content[idx+4:]
 		}
 	}
-
-	// Write to current skill file
-	return os.WriteFile(m.skillPath(name), []byte(content), 0644)
+	return content
 }
 
 // Diff returns the difference between two versions
@@ -0,0 +1,117 @@
+package skills
+
+import (
+	"fmt"
+	"strings"
+
+	"openskill/pkg/core"
+)
+
+// Resolve returns a skill composed with everything it builds on: the
+// rules and examples of its extends parent come first, then those of each
+// included skill, then its own. Repeated rules and examples are kept once. References to
+// skills that don't exist are skipped; a reference cycle is an error.
+func (m *Manager) Resolve(name string) (*core.Skill, error) {
+	return m.resolve(name, nil)
+}
+
+func (m *Manager) resolve(name string, stack []string) (*core.Skill, error) {
+	for _, seen := range stack {
+		if strings.EqualFold(seen, name) {
+			return nil, fmt.Errorf("skill composition cycle: %s -> %s", strings.Join(stack, " -> "), name)
+		}
+	}
+	stack = append(stack, name)
+
+	skill, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.compose(skill, stack)
+}
+
+// ResolveVersion is Resolve for a version saved in the skill's history;
+// version 0 is the current SKILL.md. The skills it builds on are used as
+// they are now.
+func (m *Manager) ResolveVersion(name string, version int) (*core.Skill, error) {
+	if version == 0 {
+		return m.Resolve(name)
+	}
+	skill, err := m.GetVersion(name, version)
+	if err != nil {
+		return nil, err
+	}
+	return m.compose(skill, []string{name})
+}
+
+// compose merges a skill with the skills it extends and includes; stack
+// holds the skills being resolved, ending with this one
+func (m *Manager) compose(skill *core.Skill, stack []string) (*core.Skill, error) {
+	var bases []*core.Skill
+	refs := skill.Includes
+	if skill.Extends != "" {
+		refs = append([]string{skill.Extends}, refs...)
+	}
+	for _, ref := range refs {
+		if !m.Exists(ref) {
+			continue
+		}
+		base, err := m.resolve(ref, stack)
+		if err != nil {
+			return nil, err
+		}
+		bases = append(bases, base)
+	}
+
+	resolved := cloneSkill(skill)
+	var rules []string
+	seen := map[string]bool{}
+	addRules := func(list []string) {
+		for _, rule := range list {
+			key := strings.ToLower(strings.TrimSpace(rule))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			rules = append(rules, rule)
+		}
+	}
+	for _, base := range bases {
+		addRules(base.Rules)
+	}
+	addRules(skill.Rules)
+	resolved.Rules = rules
+
+	var examples []core.Example
+	seenInput := map[string]bool{}
+	for _, list := range append(exampleLists(bases), skill.Examples) {
+		for _, ex := range list {
+			key := strings.TrimSpace(ex.Input)
+			if seenInput[key] {
+				continue
+			}
+			seenInput[key] = true
+			examples = append(examples, ex)
+		}
+	}
+	resolved.Examples = examples
+
+	if skill.Extends != "" && len(bases) > 0 && strings.EqualFold(bases[0].Name, skill.Extends) {
+		parent := bases[0]
+		if resolved.Description == "" {
+			resolved.Description = parent.Description
+		}
+		if resolved.OutputFormat == "" {
+			resolved.OutputFormat = parent.OutputFormat
+		}
+	}
+	return resolved, nil
+}
+
+func exampleLists(skills []*core.Skill) [][]core.Example {
+	var lists [][]core.Example
+	for _, s := range skills {
+		lists = append(lists, s.Examples)
+	}
+	return lists
+}
@@ -0,0 +1,278 @@
+package skills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"openskill/pkg/core"
+
+	"gopkg.in/yaml.v3"
+)
+
+const TagsFile = ".claude/tags.yaml"
+
+// TagSeparator splits hierarchical tags such as "lang/go"
+const TagSeparator = "/"
+
+// NormalizeTag lowercases a tag and cleans up its hierarchy separators
+func NormalizeTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	var parts []string
+	for _, p := range strings.Split(tag, TagSeparator) {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, TagSeparator)
+}
+
+// TagParents returns the ancestors of a hierarchical tag, nearest last
+// ("lang/go/web" -> "lang", "lang/go")
+func TagParents(tag string) []string {
+	parts := strings.Split(tag, TagSeparator)
+	var parents []string
+	for i := 1; i < len(parts); i++ {
+		parents = append(parents, strings.Join(parts[:i], TagSeparator))
+	}
+	return parents
+}
+
+// IsTagUnder reports whether tag equals parent or is one of its descendants
+func IsTagUnder(tag, parent string) bool {
+	return tag == parent || strings.HasPrefix(tag, parent+TagSeparator)
+}
+
+// LoadTagRegistry loads the tag registry, returning an empty one if none exists
+func LoadTagRegistry() (*core.TagRegistry, error) {
+	registry := &core.TagRegistry{Tags: make(map[string]*core.TagDefinition)}
+
+	data, err := os.ReadFile(TagsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", TagsFile, err)
+	}
+	if registry.Tags == nil {
+		registry.Tags = make(map[string]*core.TagDefinition)
+	}
+	return registry, nil
+}
+
+// SaveTagRegistry saves the tag registry
+func SaveTagRegistry(registry *core.TagRegistry) error {
+	if err := os.MkdirAll(filepath.Dir(TagsFile), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(registry)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(TagsFile, data, 0644)
+}
+
+// tagRegistry returns the lazily loaded registry for this manager
+func (m *Manager) tagRegistry() *core.TagRegistry {
+	if m.tags == nil {
+		registry, err := LoadTagRegistry()
+		if err != nil {
+			// A broken registry shouldn't make skills unlistable; fall back to no aliases
+			registry = &core.TagRegistry{Tags: make(map[string]*core.TagDefinition)}
+		}
+		m.tags = registry
+	}
+	return m.tags
+}
+
+// CanonicalTag normalizes a tag and resolves aliases from the registry.
+// Aliases apply to whole tags and to the leading segments of hierarchical
+// tags, so with "golang" aliased to "lang/go", "golang/web" becomes "lang/go/web".
+func (m *Manager) CanonicalTag(tag string) string {
+	tag = NormalizeTag(tag)
+	aliases := aliasMap(m.tagRegistry())
+
+	parts := strings.Split(tag, TagSeparator)
+	for i := len(parts); i > 0; i-- {
+		prefix := strings.Join(parts[:i], TagSeparator)
+		if canonical, ok := aliases[prefix]; ok {
+			return strings.Join(append([]string{canonical}, parts[i:]...), TagSeparator)
+		}
+	}
+	return tag
+}
+
+// TagMatches reports whether a skill tag satisfies a tag filter. Both are
+// canonicalized, and a parent filter ("lang") matches its children ("lang/go").
+func (m *Manager) TagMatches(tag, filter string) bool {
+	return IsTagUnder(m.CanonicalTag(tag), m.CanonicalTag(filter))
+}
+
+// aliasMap maps every normalized alias to its canonical tag
+func aliasMap(registry *core.TagRegistry) map[string]string {
+	aliases := make(map[string]string)
+	for name, def := range registry.Tags {
+		if def == nil {
+			continue
+		}
+		for _, alias := range def.Aliases {
+			aliases[NormalizeTag(alias)] = NormalizeTag(name)
+		}
+	}
+	return aliases
+}
+
+// RetagResult describes one skill changed by RewriteTags
+type RetagResult struct {
+	Skill   string
+	OldTags []string
+	NewTags []string
+}
+
+// RewriteTags applies rewrite to every tag on every skill, saving a version
+// of each skill before changing it. rewrite receives canonical tags and
+// returns the replacement; duplicates produced by the rewrite are dropped.
+func (m *Manager) RewriteTags(rewrite func(tag string) string) ([]RetagResult, error) {
+	all, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []RetagResult
+	for i := range all {
+		skill := &all[i]
+
+		// Skills the rewrite doesn't touch are left alone, even if they
+		// carry duplicate spellings of a tag
+		changed := false
+		for _, t := range skill.Tags {
+			if ct := m.CanonicalTag(t); ct != "" && rewrite(ct) != ct {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		var newTags []string
+		for _, t := range skill.Tags {
+			ct := m.CanonicalTag(t)
+			nt := rewrite(ct)
+			if nt == ct {
+				// Untouched tags keep their original spelling
+				nt = t
+			}
+			if ct == "" || seen[m.CanonicalTag(nt)] {
+				continue
+			}
+			seen[m.CanonicalTag(nt)] = true
+			newTags = append(newTags, nt)
+		}
+
+		if err := m.SaveVersion(skill.Name); err != nil {
+			return results, fmt.Errorf("failed to save version of '%s': %w", skill.Name, err)
+		}
+
+		oldTags := skill.Tags
+		skill.Tags = newTags
+		if err := m.save(skill); err != nil {
+			return results, fmt.Errorf("failed to update '%s': %w", skill.Name, err)
+		}
+		results = append(results, RetagResult{Skill: skill.Name, OldTags: oldTags, NewTags: newTags})
+	}
+
+	return results, nil
+}
+
+// RenameTag replaces a tag (and its descendants) with another on every skill
+func (m *Manager) RenameTag(from, to string) ([]RetagResult, error) {
+	return m.MergeTags([]string{from}, to)
+}
+
+// MergeTags folds each source tag (and its descendants) into target. Every
+// source must be used by some skill, and target can't sit under a source.
+func (m *Manager) MergeTags(sources []string, target string) ([]RetagResult, error) {
+	target = m.CanonicalTag(target)
+	if target == "" {
+		return nil, fmt.Errorf("target tag is empty")
+	}
+	counts, err := m.TagCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var from []string
+	for _, s := range sources {
+		s = m.CanonicalTag(s)
+		if s == "" {
+			return nil, fmt.Errorf("source tag is empty")
+		}
+		if s == target {
+			return nil, fmt.Errorf("cannot merge tag '%s' into itself", s)
+		}
+		if IsTagUnder(target, s) {
+			// Renaming lang to lang/go would turn lang/go into lang/go/go
+			return nil, fmt.Errorf("cannot merge tag '%s' into its own child '%s'", s, target)
+		}
+		if counts[s] == 0 {
+			return nil, fmt.Errorf("tag '%s' is not used by any skill", s)
+		}
+		from = append(from, s)
+	}
+
+	return m.RewriteTags(func(tag string) string {
+		for _, s := range from {
+			if IsTagUnder(tag, s) {
+				return target + strings.TrimPrefix(tag, s)
+			}
+		}
+		return tag
+	})
+}
+
+// TagCounts returns the number of skills carrying each canonical tag.
+// Parent tags are counted for skills tagged with any of their children.
+func (m *Manager) TagCounts() (map[string]int, error) {
+	all, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, skill := range all {
+		seen := make(map[string]bool)
+		for _, t := range skill.Tags {
+			tag := m.CanonicalTag(t)
+			if tag == "" {
+				continue
+			}
+			for _, name := range append(TagParents(tag), tag) {
+				if !seen[name] {
+					seen[name] = true
+					counts[name]++
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+// sortedKeys returns the keys of a count map in order
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
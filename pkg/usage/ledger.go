@@ -0,0 +1,87 @@
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"openskill/pkg/config"
+)
+
+// Entry is one provider call in the ledger
+type Entry struct {
+	Time             time.Time `json:"time"`
+	Command          string    `json:"command,omitempty"`
+	Skill            string    `json:"skill,omitempty"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model,omitempty"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	Cost             float64   `json:"cost"`             // USD
+	Priced           bool      `json:"priced"`           // False when the model has no known price
+	Cached           bool      `json:"cached,omitempty"` // Served from cache or a fixture
+}
+
+// LedgerPath returns ~/.openskill/usage.jsonl
+func LedgerPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "usage.jsonl"), nil
+}
+
+// Append adds an entry to the ledger
+func Append(e Entry) error {
+	path, err := LedgerPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// ReadLedger returns the entries recorded at or after since. Lines that
+// don't parse are skipped.
+func ReadLedger(since time.Time) ([]Entry, error) {
+	path, err := LedgerPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if !e.Time.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"strings"
+
+	"openskill/pkg/config"
+)
+
+// DefaultPrices are list prices in USD per million tokens. Users override
+// or extend them under prices in ~/.openskill/config.yaml.
+var DefaultPrices = map[string]config.ModelPrice{
+	// Groq
+	"llama-3.3-70b-versatile": {Input: 0.59, Output: 0.79},
+	"llama-3.1-8b-instant":    {Input: 0.05, Output: 0.08},
+	"mixtral-8x7b-32768":      {Input: 0.24, Output: 0.24},
+
+	// OpenAI
+	"gpt-4o-mini": {Input: 0.15, Output: 0.60},
+	"gpt-4o":      {Input: 2.50, Output: 10.00},
+	"gpt-4-turbo": {Input: 10.00, Output: 30.00},
+	"o1-mini":     {Input: 3.00, Output: 12.00},
+
+	// Anthropic
+	"claude-3-5-sonnet": {Input: 3.00, Output: 15.00},
+	"claude-3-5-haiku":  {Input: 0.80, Output: 4.00},
+	"claude-3-opus":     {Input: 15.00, Output: 75.00},
+	"claude-3-haiku":    {Input: 0.25, Output: 1.25},
+}
+
+// freeProviders run locally and cost nothing
+var freeProviders = map[string]bool{"ollama": true}
+
+// PriceFor finds the price of a model. Exact names win; otherwise the
+// longest known prefix matches, so dated snapshots like
+// gpt-4o-mini-2024-07-18 use the gpt-4o-mini price.
+func PriceFor(provider, model string) (config.ModelPrice, bool) {
+	if freeProviders[strings.ToLower(provider)] {
+		return config.ModelPrice{}, true
+	}
+
+	prices := make(map[string]config.ModelPrice, len(DefaultPrices))
+	for name, p := range DefaultPrices {
+		prices[name] = p
+	}
+	if cfg, err := config.Load(); err == nil {
+		for name, p := range cfg.Prices {
+			prices[name] = p
+		}
+	}
+
+	model = strings.ToLower(model)
+	if p, ok := prices[model]; ok {
+		return p, true
+	}
+	best := ""
+	for name := range prices {
+		if strings.HasPrefix(model, strings.ToLower(name)) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return config.ModelPrice{}, false
+	}
+	return prices[best], true
+}
+
+// Cost returns the USD cost of a completion at price p
+func Cost(p config.ModelPrice, promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.Input + float64(completionTokens)*p.Output) / 1e6
+}
@@ -0,0 +1,90 @@
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Groupings accepted by Summarize
+var Groupings = []string{"day", "command", "provider", "model", "skill"}
+
+// Total aggregates ledger entries
+type Total struct {
+	Key              string  `json:"key" yaml:"key"`
+	Calls            int     `json:"calls" yaml:"calls"`
+	CachedCalls      int     `json:"cached_calls" yaml:"cached_calls"`
+	PromptTokens     int     `json:"prompt_tokens" yaml:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens" yaml:"completion_tokens"`
+	Cost             float64 `json:"cost" yaml:"cost"`
+	UnpricedCalls    int     `json:"unpriced_calls" yaml:"unpriced_calls"` // Calls whose cost is unknown
+}
+
+func (t *Total) add(e Entry) {
+	t.Calls++
+	if e.Cached {
+		t.CachedCalls++
+		return
+	}
+	t.PromptTokens += e.PromptTokens
+	t.CompletionTokens += e.CompletionTokens
+	t.Cost += e.Cost
+	if !e.Priced {
+		t.UnpricedCalls++
+	}
+}
+
+// Summarize groups entries by day, command, provider, model or skill and
+// returns the groups (most expensive first, or by date for days) and the
+// overall total
+func Summarize(entries []Entry, by string) ([]Total, Total, error) {
+	key, err := groupKey(by)
+	if err != nil {
+		return nil, Total{}, err
+	}
+
+	overall := Total{Key: "total"}
+	groups := map[string]*Total{}
+	for _, e := range entries {
+		overall.add(e)
+		k := key(e)
+		if k == "" {
+			k = "(none)"
+		}
+		if groups[k] == nil {
+			groups[k] = &Total{Key: k}
+		}
+		groups[k].add(e)
+	}
+
+	out := make([]Total, 0, len(groups))
+	for _, t := range groups {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if by == "day" {
+			return out[i].Key < out[j].Key
+		}
+		if out[i].Cost != out[j].Cost {
+			return out[i].Cost > out[j].Cost
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, overall, nil
+}
+
+func groupKey(by string) (func(Entry) string, error) {
+	switch by {
+	case "day":
+		return func(e Entry) string { return e.Time.Local().Format("2006-01-02") }, nil
+	case "command":
+		return func(e Entry) string { return e.Command }, nil
+	case "provider":
+		return func(e Entry) string { return strings.ToLower(e.Provider) }, nil
+	case "model":
+		return func(e Entry) string { return e.Model }, nil
+	case "skill":
+		return func(e Entry) string { return e.Skill }, nil
+	}
+	return nil, fmt.Errorf("invalid grouping '%s' (valid: %s)", by, strings.Join(Groupings, ", "))
+}
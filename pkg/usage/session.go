@@ -0,0 +1,93 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ============== Labels ==============
+
+type labelKey struct{}
+
+type labels struct {
+	command string
+	skill   string
+}
+
+// WithCommand labels provider calls made under ctx with a command name
+func WithCommand(ctx context.Context, command string) context.Context {
+	l := labelsFrom(ctx)
+	l.command = command
+	return context.WithValue(ctx, labelKey{}, l)
+}
+
+// WithSkill labels provider calls made under ctx with a skill name
+func WithSkill(ctx context.Context, skill string) context.Context {
+	l := labelsFrom(ctx)
+	l.skill = skill
+	return context.WithValue(ctx, labelKey{}, l)
+}
+
+// Labels returns the command and skill attached to ctx
+func Labels(ctx context.Context) (command, skill string) {
+	l := labelsFrom(ctx)
+	return l.command, l.skill
+}
+
+func labelsFrom(ctx context.Context) labels {
+	if ctx == nil {
+		return labels{}
+	}
+	l, _ := ctx.Value(labelKey{}).(labels)
+	return l
+}
+
+// ============== Budget ==============
+
+// BudgetError is returned once this invocation has spent its --budget
+type BudgetError struct {
+	Budget float64
+	Spent  float64
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("budget of $%.4f reached ($%.4f spent); raise --budget to continue", e.Budget, e.Spent)
+}
+
+var session struct {
+	sync.Mutex
+	budget float64 // 0 means no cap
+	spent  float64
+}
+
+// SetBudget caps what this process may spend, in USD; 0 removes the cap
+func SetBudget(usd float64) {
+	session.Lock()
+	defer session.Unlock()
+	session.budget = usd
+}
+
+// Charge records spending against the budget
+func Charge(usd float64) {
+	session.Lock()
+	defer session.Unlock()
+	session.spent += usd
+}
+
+// Spent returns what this process has spent so far
+func Spent() float64 {
+	session.Lock()
+	defer session.Unlock()
+	return session.spent
+}
+
+// CheckBudget fails once spending has reached the budget
+func CheckBudget() error {
+	session.Lock()
+	defer session.Unlock()
+	if session.budget > 0 && session.spent >= session.budget {
+		return &BudgetError{Budget: session.budget, Spent: session.spent}
+	}
+	return nil
+}